@@ -3,7 +3,7 @@ package main
 import (
 	"context"
 	"log"
-	"net/http"
+	"log/slog"
 	"os"
 
 	"github.com/equalsgibson/five9-go/five9"
@@ -18,11 +18,9 @@ func main() {
 			Username: os.Getenv("FIVE9USERNAME"),
 			Password: os.Getenv("FIVE9PASSWORD"),
 		},
-		five9.AddRequestPreprocessor(func(r *http.Request) error {
-			log.Printf("five9 Rest API Call: [%s] %s", r.Method, r.URL.String())
-
-			return nil
-		}),
+		five9.SetLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		}))),
 	)
 
 	domainUsers, err := c.Supervisor().GetAllDomainUsers(ctx)
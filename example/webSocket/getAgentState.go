@@ -3,28 +3,15 @@ package main
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"net/http"
+	"log/slog"
 	"os"
-	"runtime"
-	"runtime/pprof"
 	"time"
 
 	"github.com/equalsgibson/five9-go/five9"
 	"github.com/equalsgibson/five9-go/five9/five9types"
 )
 
-func startConnection(ctx context.Context, c *five9.Service) error {
-	if err := c.Supervisor().StartWebsocket(ctx); err != nil {
-		if !errors.Is(err, context.Canceled) {
-			return fmt.Errorf("websocket exiting, restarting. Here is the error message: %s", err.Error())
-		}
-	}
-
-	return nil
-}
-
 func main() {
 	ctx := context.Background()
 
@@ -33,11 +20,9 @@ func main() {
 			Username: os.Getenv("FIVE9USERNAME"),
 			Password: os.Getenv("FIVE9PASSWORD"),
 		},
-		five9.AddRequestPreprocessor(func(r *http.Request) error {
-			log.Printf("five9 Rest API Call: [%s] %s", r.Method, r.URL.String())
-
-			return nil
-		}),
+		five9.SetLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		}))),
 	)
 
 	// Run a function every 5 seconds to obtain some information from the
@@ -58,28 +43,20 @@ func main() {
 		}
 	}()
 
-	count := runtime.NumGoroutine()
-	defer func() {
-		time.Sleep(time.Second)
-		diff := runtime.NumGoroutine() - count
-		pprof.Lookup("goroutine").WriteTo(os.Stdout, 1)
-		if diff > 0 {
-			log.Printf("Too many goruoutes: %d extra (%d now and started with %d)", diff, runtime.NumGoroutine(), count)
-		} else {
-			log.Printf("Looking good")
-		}
-	}()
-
-	errCount := 0
-	for {
-		if err := startConnection(ctx, c); err != nil {
-			errCount++
-			log.Print(err)
-			time.Sleep(time.Second * 1)
-		}
-
-		if errCount > 3 {
-			return
-		}
+	// Keep the websocket connected, reconnecting with a backoff whenever the connection drops.
+	err := c.Supervisor().RunWebsocket(ctx, five9.WebsocketRunnerConfig{
+		MaxAttempts: 5,
+		OnConnected: func() {
+			log.Print("websocket connected")
+		},
+		OnDisconnected: func(err error) {
+			log.Printf("websocket disconnected: %s", err)
+		},
+		OnResynced: func() {
+			log.Print("websocket cache resynchronized")
+		},
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatal(err)
 	}
 }
@@ -1,22 +1,28 @@
 package five9
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/equalsgibson/five9-go/five9/five9types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type authenticationState struct {
 	client         *client
 	loginResponse  *five9types.LoginResponse
 	loginMutex     *sync.Mutex
+	apiHosts       *apiHostList
 	apiContextPath string
+
+	sessionRestoreAttempted bool
 }
 
 func (a *authenticationState) endpointGetSessionMetadata(ctx context.Context) error {
@@ -34,112 +40,300 @@ func (a *authenticationState) endpointGetSessionMetadata(ctx context.Context) er
 }
 
 func (a *authenticationState) requestWithAuthentication(request *http.Request, target any) error {
-	login, err := a.getLogin(request.Context())
+	return a.retry(request, func(attemptRequest *http.Request) error {
+		return a.client.request(attemptRequest, target)
+	})
+}
+
+func (a *authenticationState) requestDownloadWithAuthentication(request *http.Request) (*http.Response, error) {
+	var response *http.Response
+
+	err := a.retry(request, func(attemptRequest *http.Request) error {
+		attemptResponse, err := a.client.requestDownload(attemptRequest)
+		if err != nil {
+			return err
+		}
+
+		response = attemptResponse
+
+		return nil
+	})
 	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// retry sends the request to the API server of the current login, retrying failed attempts according to the
+// client's RetryPolicy. Each attempt gets its own copy of the request, with the :userID and :organizationID
+// placeholders in the path filled in.
+func (a *authenticationState) retry(request *http.Request, attempt func(*http.Request) error) (err error) {
+	ctx, endAPICall := a.client.telemetry.startAPICall(request, a.apiContextPath)
+	defer func() { endAPICall(err) }()
+
+	request = request.WithContext(ctx)
+
+	login, err := a.getLogin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := replayableBody(request); err != nil {
 		return err
 	}
 
-	request.URL.Scheme = "https"
-	request.URL.Host = login.GetAPIHost()
-	request.URL.Path = strings.ReplaceAll(request.URL.Path, ":userID", string(login.UserID))
-	request.URL.Path = strings.ReplaceAll(request.URL.Path, ":organizationID", string(login.OrgID))
-
-	var latestAttemptErr error
-	tries := 0
-	for tries < 3 {
-		tries++
-		latestAttemptErr = a.client.request(request, target)
-		if latestAttemptErr != nil {
-			if five9Error, ok := latestAttemptErr.(*Error); ok {
-				if five9Error.StatusCode == http.StatusUnauthorized {
-					// The login is not registered by other endpoints for a short time.
-					// I think this has to do with Five9 propagating the session across their data centers.
-					// We login using the app.five9.com domain but then make subsequent calls to the data center specific domain
-					time.Sleep(time.Second * 2)
-
-					continue
-				}
-
-				// Five9 reply with Status 435 if a service has been migrated. This is not an official status code, so check directly.
-				if five9Error.StatusCode == int(435) {
-					// Clear out the login state
-					a.loginMutex.Lock()
-					defer a.loginMutex.Unlock()
-
-					a.loginResponse = nil
-
-					return latestAttemptErr
-				}
+	policy := a.client.retryPolicy
+	failovers := 0
+	loggedInAgain := false
+
+	for attempts := 1; ; attempts++ {
+		apiHost := a.apiHost(login)
+
+		attemptRequest, err := a.prepareRequest(request, login, apiHost, RequestAttempt{
+			Attempt:       attempts,
+			LoggedInAgain: loggedInAgain,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = attempt(attemptRequest)
+		if err == nil {
+			return nil
+		}
+
+		if isUnreachable(err) && failovers < a.apiHosts.len() && a.apiHosts.failover(apiHost) {
+			a.client.logger.WarnContext(ctx, "five9 API server unreachable, failing over",
+				"api_host", apiHost,
+				"error", err,
+			)
+
+			trace.SpanFromContext(ctx).AddEvent("five9 failover", trace.WithAttributes(
+				attribute.String("five9.api_host", apiHost),
+			))
+
+			// Trying another server does not count as an attempt.
+			failovers++
+			attempts--
+
+			continue
+		}
+
+		// Five9 reply with Status 435 if a service has been migrated. This is not an official status code, so check directly.
+		five9Error := &Error{}
+		if errors.As(err, &five9Error) && five9Error.StatusCode == int(435) {
+			// A 435 while logging in means the login state is wrong, logging in again from inside the login won't help.
+			// Only log in again once per request, so a session that keeps being migrated can't loop forever.
+			if _, loggingIn := a.inProgressLogin(ctx); loggingIn || loggedInAgain {
+				return err
+			}
+
+			a.client.logger.WarnContext(ctx, "five9 service migrated, logging in again",
+				"method", request.Method,
+				"path", request.URL.Path,
+				"api_context", a.apiContextPath,
+			)
+
+			trace.SpanFromContext(ctx).AddEvent("five9 service migrated")
+
+			a.clearLogin(login)
+
+			login, err = a.getLogin(ctx)
+			if err != nil {
+				a.client.logger.ErrorContext(ctx, "five9 login after service migration failed", "error", err)
+
+				return fmt.Errorf("%w, logging in again failed: %w", ErrServiceMigrated, err)
 			}
+
+			// Replay the request against the API server of the new login. This is not counted as an attempt.
+			loggedInAgain = true
+			attempts--
+
+			continue
 		}
 
-		return nil
+		if attempts >= policy.MaxAttempts || !policy.Retryable(err) {
+			return err
+		}
+
+		backoff, ok := policy.backoff(attempts, err)
+		if !ok {
+			a.client.logger.WarnContext(ctx, "five9 request failed, Retry-After is longer than the maximum backoff",
+				"method", request.Method,
+				"path", request.URL.Path,
+				"attempt", attempts,
+				"error", err,
+			)
+
+			return err
+		}
+
+		a.client.logger.WarnContext(ctx, "five9 request failed, retrying",
+			"method", request.Method,
+			"path", request.URL.Path,
+			"attempt", attempts,
+			"backoff", backoff,
+			"error", err,
+		)
+
+		trace.SpanFromContext(ctx).AddEvent("five9 retry", trace.WithAttributes(
+			attribute.Int("five9.attempt", attempts),
+			attribute.String("five9.backoff", backoff.String()),
+		))
+
+		if waitErr := policy.wait(ctx, backoff); waitErr != nil {
+			return waitErr
+		}
+	}
+}
+
+// requestWithLogin sends a single attempt of request with login, without retrying or logging in again.
+func (a *authenticationState) requestWithLogin(request *http.Request, login *five9types.LoginResponse, target any) error {
+	attemptRequest, err := a.prepareRequest(request, login, a.apiHost(login), RequestAttempt{
+		Attempt: 1,
+	})
+	if err != nil {
+		return err
 	}
 
-	return latestAttemptErr
+	return a.client.request(attemptRequest, target)
 }
-func (a *authenticationState) requestDownloadWithAuthentication(request *http.Request) (*http.Response, error) {
-	login, err := a.getLogin(request.Context())
+
+func (a *authenticationState) prepareRequest(
+	request *http.Request,
+	login *five9types.LoginResponse,
+	apiHost string,
+	attempt RequestAttempt,
+) (*http.Request, error) {
+	attemptRequest := request.Clone(withRequestAttempt(request.Context(), attempt))
+
+	// The body of the previous attempt has already been read, so start again with a fresh copy.
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		attemptRequest.Body = body
+	}
+
+	attemptRequest.URL.Scheme = "https"
+	attemptRequest.URL.Host = apiHost
+	attemptRequest.URL.Path = strings.ReplaceAll(attemptRequest.URL.Path, ":userID", string(login.UserID))
+	attemptRequest.URL.Path = strings.ReplaceAll(attemptRequest.URL.Path, ":organizationID", string(login.OrgID))
+
+	return attemptRequest, nil
+}
+
+// replayableBody makes sure the body of request can be read once per attempt. Bodies created by
+// http.NewRequestWithContext from a bytes or strings reader already can, anything else is buffered in memory.
+func replayableBody(request *http.Request) error {
+	if request.Body == nil || request.Body == http.NoBody || request.GetBody != nil {
+		return nil
+	}
+
+	bodyBytes, err := io.ReadAll(request.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	request.URL.Scheme = "https"
-	request.URL.Host = login.GetAPIHost()
-	request.URL.Path = strings.ReplaceAll(request.URL.Path, ":userID", string(login.UserID))
-	request.URL.Path = strings.ReplaceAll(request.URL.Path, ":organizationID", string(login.OrgID))
+	if err := request.Body.Close(); err != nil {
+		return err
+	}
+
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(bodyBytes)), nil
+	}
 
-	var latestAttemptErr error
-	tries := 0
-	for tries < 3 {
-		tries++
-		response, latestAttemptErr := a.client.requestDownload(request)
-		if latestAttemptErr != nil {
-			if five9Error, ok := latestAttemptErr.(*Error); ok {
-				if five9Error.StatusCode == http.StatusUnauthorized {
-					time.Sleep(time.Second * 2)
-					continue
-				}
+	return nil
+}
 
-				if five9Error.StatusCode == int(435) {
-					a.loginMutex.Lock()
-					defer a.loginMutex.Unlock()
+// apiHost returns the API server that requests for login should be sent to.
+func (a *authenticationState) apiHost(login *five9types.LoginResponse) string {
+	return a.apiHosts.host(login.GetAPIHost())
+}
 
-					a.loginResponse = nil
+// clearLogin drops login so the next request logs in again. If another request has already replaced login,
+// the newer login is kept.
+func (a *authenticationState) clearLogin(login *five9types.LoginResponse) {
+	a.loginMutex.Lock()
+	defer a.loginMutex.Unlock()
 
-					return nil, latestAttemptErr
-				}
-			}
-		} else {
-			return response, nil
-		}
+	if a.loginResponse == login {
+		a.loginResponse = nil
 	}
+}
+
+type loggingInContextKey struct{}
+
+// loginInProgress is the login that getLogin is setting up. The requests that are part of the login run while
+// loginMutex is held, so they use it from their context instead of waiting for the mutex. Requests of other
+// authentication states made during the login, for example by a TokenProvider, ignore it.
+type loginInProgress struct {
+	state *authenticationState
+	login *five9types.LoginResponse
+}
 
-	return nil, latestAttemptErr
+// inProgressLogin returns the login that the request is part of, for example the login_state or session_start calls.
+func (a *authenticationState) inProgressLogin(ctx context.Context) (*loginInProgress, bool) {
+	inProgress, ok := ctx.Value(loggingInContextKey{}).(*loginInProgress)
+	if !ok || inProgress.state != a {
+		return nil, false
+	}
+
+	return inProgress, true
 }
 
 func (a *authenticationState) getLogin(
 	ctx context.Context,
-) (*five9types.LoginResponse, error) {
-	{ // check for existing login
-		if a.loginResponse != nil {
-			return a.loginResponse, nil
+) (_ *five9types.LoginResponse, err error) {
+	if inProgress, ok := a.inProgressLogin(ctx); ok {
+		if inProgress.login == nil {
+			// A request made before the login response arrived, which would otherwise wait for its own login.
+			return nil, errors.New("five9 login has not been received yet")
 		}
 
-		a.loginMutex.Lock()
-		defer a.loginMutex.Unlock()
+		return inProgress.login, nil
+	}
 
-		if a.loginResponse != nil {
-			return a.loginResponse, nil
-		}
+	a.loginMutex.Lock()
+	defer a.loginMutex.Unlock()
+
+	if a.loginResponse != nil {
+		return a.loginResponse, nil
+	}
+
+	inProgress := &loginInProgress{state: a}
+	ctx = context.WithValue(ctx, loggingInContextKey{}, inProgress)
+
+	ctx, span := a.client.telemetry.tracer.Start(ctx, "five9 login", trace.WithAttributes(
+		attribute.String("five9.api_context", a.apiContextPath),
+	))
+	defer func() { endSpan(span, err) }()
+
+	if token, ok := a.restoreSession(ctx); ok {
+		a.loginResponse = &token.LoginResponse
+
+		return a.loginResponse, nil
 	}
 
-	login, err := a.endpointLogin(ctx)
+	login, err := a.newLogin(ctx)
 	if err != nil {
+		a.client.logger.ErrorContext(ctx, "five9 login failed", "api_context", a.apiContextPath, "error", err)
+
 		return nil, err
 	}
 
-	a.loginResponse = &login
+	a.client.logger.InfoContext(ctx, "five9 logged in", "api_context", a.apiContextPath, "login", login)
+
+	span.SetAttributes(
+		attribute.String("five9.user_id", string(login.UserID)),
+		attribute.String("five9.api_host", login.GetAPIHost()),
+	)
+
+	a.apiHosts.reset(a.client.apiServerStrategy(login))
+	inProgress.login = &login
 
 	if err := a.endpointGetSessionMetadata(ctx); err != nil {
 		return nil, err
@@ -150,6 +344,12 @@ func (a *authenticationState) getLogin(
 		return nil, err
 	}
 
+	a.client.logger.InfoContext(ctx, "five9 login state",
+		"api_context", a.apiContextPath,
+		"user_id", login.UserID,
+		"login_state", loginState,
+	)
+
 	switch loginState {
 	case five9types.UserLoginStateSelectStation: // Standard response after logging in.
 		if err := a.endpointStartSession(ctx); err != nil {
@@ -163,13 +363,13 @@ func (a *authenticationState) getLogin(
 		}
 
 		if newLoginState == five9types.UserLoginStateAcceptNotice {
-			if err := a.handleMaintenanceNotices(ctx); err != nil {
+			if err := a.handleMaintenanceNotices(ctx, &login); err != nil {
 				return nil, err
 			}
 		}
 
 	case five9types.UserLoginStateAcceptNotice: // Can occur if Five9 have issued a maintenance notice
-		if err := a.handleMaintenanceNotices(ctx); err != nil {
+		if err := a.handleMaintenanceNotices(ctx, &login); err != nil {
 			return nil, err
 		}
 
@@ -185,35 +385,73 @@ func (a *authenticationState) getLogin(
 		}
 
 		if newLoginState == five9types.UserLoginStateAcceptNotice {
-			if err := a.handleMaintenanceNotices(ctx); err != nil {
+			if err := a.handleMaintenanceNotices(ctx, &login); err != nil {
 				return nil, err
 			}
 		}
 	}
 
+	// Only share the login once it is ready, so a failed login is tried again by the next request.
+	a.loginResponse = &login
+	a.saveSession(ctx, a.tokenFor(a.loginResponse))
+
 	return a.loginResponse, nil
 }
 
+// newLogin logs in, or takes the login from the token provider if there is one.
+func (a *authenticationState) newLogin(ctx context.Context) (five9types.LoginResponse, error) {
+	if a.client.tokenProvider == nil {
+		return a.endpointLogin(ctx)
+	}
+
+	a.client.logger.InfoContext(ctx, "five9 using token from provider", "api_context", a.apiContextPath)
+
+	token, err := a.client.tokenProvider.Token(ctx, a.apiContext())
+	if err != nil {
+		return five9types.LoginResponse{}, err
+	}
+
+	a.useToken(token)
+
+	return token.LoginResponse, nil
+}
+
 func (a *authenticationState) endpointLogin(ctx context.Context) (five9types.LoginResponse, error) {
+	credentials, err := a.client.credentialsProvider.Credentials(ctx)
+	if err != nil {
+		return five9types.LoginResponse{}, err
+	}
+
+	a.client.logger.InfoContext(ctx, "five9 logging in",
+		"api_context", a.apiContextPath,
+		"credentials", credentials,
+		"policy", a.client.loginPolicy,
+	)
+
 	payload := five9types.LoginPayload{
-		PasswordCredentials: a.client.credentials,
+		PasswordCredentials: credentials,
 		AppKey:              "web-ui",
-		Policy:              five9types.PolicyForceIn,
+		Policy:              a.client.loginPolicy,
 	}
 
 	request, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
-		fmt.Sprintf("https://app.five9.com/%s/auth/login", a.apiContextPath),
+		fmt.Sprintf("%s/%s/auth/login", a.client.loginBaseURL, a.apiContextPath),
 		structToReaderCloser(payload),
 	)
 	if err != nil {
 		return five9types.LoginResponse{}, err
 	}
 
+	ctx, endAPICall := a.client.telemetry.startAPICall(request, a.apiContextPath)
+
 	target := five9types.LoginResponse{}
 
-	if err := a.client.request(request, &target); err != nil {
+	err = a.client.request(request.WithContext(ctx), &target)
+	endAPICall(err)
+
+	if err != nil {
 		return five9types.LoginResponse{}, err
 	}
 
@@ -316,16 +554,27 @@ func (a *authenticationState) endpointRestartSession(ctx context.Context) error
 	return nil
 }
 
-func (a *authenticationState) handleMaintenanceNotices(ctx context.Context) error {
+func (a *authenticationState) handleMaintenanceNotices(ctx context.Context, login *five9types.LoginResponse) error {
 	notices, err := a.endpointGetMaintenanceNotices(ctx)
 	if err != nil {
 		return err
 	}
 
+	rejected := []five9types.MaintenanceNoticeInfo{}
+
 	for _, notice := range notices {
-		if err := a.endpointAcceptMaintenanceNotice(ctx, notice.ID); err != nil {
+		accepted, err := a.handleMaintenanceNotice(ctx, login, notice)
+		if err != nil {
 			return err
 		}
+
+		if !accepted {
+			rejected = append(rejected, notice)
+		}
+	}
+
+	if len(rejected) > 0 {
+		return &MaintenanceNoticesError{Notices: rejected}
 	}
 
 	loginState, err := a.endpointGetLoginState(ctx)
@@ -2,7 +2,9 @@ package five9_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/equalsgibson/five9-go/five9"
@@ -251,3 +253,113 @@ func Test_Authentication_AcceptNotices(t *testing.T) {
 		t.Fatalf("expected 2 users to be found, got %d", len(users))
 	}
 }
+
+func Test_Authentication_ServiceMigrated_LogsInAgain(t *testing.T) {
+	ctx := context.Background()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_435.json"),
+					StatusCode: 435,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // https://app.five9.com/supsvcs/rs/svc/auth/login
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisorLogin_migrated_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/auth/metadata
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisorLogin_migrated_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/login_state
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/loginState_relogin_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/session_restart
+				if !strings.HasSuffix(r.URL.Path, "/session_restart") {
+					t.Errorf("expected the session to be restarted, got %s", r.URL.Path)
+				}
+
+				return &http.Response{
+					Body:       http.NoBody,
+					StatusCode: http.StatusNoContent,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/login_state
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/loginState_working_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				if r.URL.Host != "app-xyz.five9.com:443" {
+					t.Errorf("expected the request to be replayed against the new data center, got %s", r.URL.Host)
+				}
+
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+	)
+
+	users, err := s.Supervisor().GetAllDomainUsers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) == 0 {
+		t.Fatal("expected users to be returned")
+	}
+
+	if len(mockRoundTripper.Func) != 0 {
+		t.Fatalf("did not make all expected API calls - %d api requests remaining in queue", len(mockRoundTripper.Func))
+	}
+}
+
+func Test_Authentication_ServiceMigrated_LoginFails(t *testing.T) {
+	ctx := context.Background()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_435.json"),
+					StatusCode: 435,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // https://app.five9.com/supsvcs/rs/svc/auth/login
+				return &http.Response{
+					Body:       http.NoBody,
+					StatusCode: http.StatusUnauthorized,
+				}, nil
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+	)
+
+	_, err := s.Supervisor().GetAllDomainUsers(ctx)
+	if !errors.Is(err, five9.ErrServiceMigrated) {
+		t.Fatalf("expected ErrServiceMigrated, got %v", err)
+	}
+}
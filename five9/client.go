@@ -4,15 +4,31 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/equalsgibson/five9-go/five9/five9types"
 )
 
 type client struct {
-	httpClient           *http.Client
-	credentials          five9types.PasswordCredentials
-	requestPreProcessors []func(r *http.Request) error
+	httpClient                 *http.Client
+	credentialsProvider        CredentialsProvider
+	tokenProvider              TokenProvider
+	sessionStore               SessionStore
+	maintenanceNoticePolicy    MaintenanceNoticePolicy
+	maintenanceNoticeRecorders []func(MaintenanceNoticeRecord)
+	loginPolicy                five9types.Policy
+	loginBaseURL               string
+	apiServerStrategy          APIServerStrategy
+	retryPolicy                RetryPolicy
+	requestPreProcessors       []func(r *http.Request) error
+	requestMiddleware          []RequestMiddleware
+	requestObservers           []func(RequestAttempt)
+	logger                     *slog.Logger
+	telemetry                  *telemetry
+	rateLimiter                *RateLimiter
 }
 
 const (
@@ -24,7 +40,7 @@ const (
 	// statisticsPath = ""
 )
 
-func (c *client) request(request *http.Request, target any) error {
+func (c *client) request(request *http.Request, target any) (err error) {
 	request.Header.Set("Accept", "application/json")
 	request.Header.Set("Content-Type", "application/json")
 
@@ -34,28 +50,23 @@ func (c *client) request(request *http.Request, target any) error {
 		}
 	}
 
-	response, err := c.httpClient.Do(request)
+	start := time.Now()
+	statusCode := 0
+
+	defer func() {
+		c.observe(request, statusCode, start, err)
+	}()
+
+	response, err := c.send(request)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode >= http.StatusBadRequest {
-		bodyBytes, err := io.ReadAll(response.Body)
-		if err != nil {
-			return err
-		}
+	statusCode = response.StatusCode
 
-		responseErr := &Error{
-			StatusCode: response.StatusCode,
-			Body:       bodyBytes,
-		}
-
-		if err := json.Unmarshal(bodyBytes, responseErr); err != nil {
-			return err
-		}
-
-		return responseErr
+	if response.StatusCode >= http.StatusBadRequest {
+		return newResponseError(request, response)
 	}
 
 	if target != nil {
@@ -81,7 +92,7 @@ func structToReaderCloser(v any) io.Reader {
 	return bytes.NewReader(vBytes)
 }
 
-func (c *client) requestDownload(request *http.Request) (*http.Response, error) {
+func (c *client) requestDownload(request *http.Request) (_ *http.Response, err error) {
 	request.Header.Set("Accept", "*/*")
 	request.Header.Set("Content-Type", "application/json")
 
@@ -91,10 +102,47 @@ func (c *client) requestDownload(request *http.Request) (*http.Response, error)
 		}
 	}
 
-	response, err := c.httpClient.Do(request)
+	start := time.Now()
+	statusCode := 0
+
+	defer func() {
+		c.observe(request, statusCode, start, err)
+	}()
+
+	response, err := c.send(request)
 	if err != nil {
 		return nil, err
 	}
 
+	statusCode = response.StatusCode
+
+	if response.StatusCode >= http.StatusBadRequest {
+		defer response.Body.Close()
+
+		return nil, newResponseError(request, response)
+	}
+
 	return response, nil
 }
+
+func newResponseError(request *http.Request, response *http.Response) error {
+	bodyBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	responseErr := &Error{
+		StatusCode: response.StatusCode,
+		Body:       bodyBytes,
+		Method:     request.Method,
+		URL:        request.URL.String(),
+		RetryAfter: parseRetryAfter(response.Header.Get("Retry-After")),
+	}
+
+	// Errors from proxies and load balancers, such as a 503, are not always Five9 exceptions, or even JSON.
+	if err := json.Unmarshal(bodyBytes, responseErr); err != nil || responseErr.Message == "" {
+		responseErr.Message = strings.TrimSpace(string(bodyBytes))
+	}
+
+	return responseErr
+}
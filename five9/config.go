@@ -1,7 +1,13 @@
 package five9
 
 import (
+	"log/slog"
 	"net/http"
+	"strings"
+
+	"github.com/equalsgibson/five9-go/five9/five9types"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ConfigFunc func(*Service)
@@ -15,12 +21,141 @@ func AddRequestPreprocessor(things ...func(*http.Request) error) ConfigFunc {
 	}
 }
 
-func SetWebsocketHandler(w webSocketHandler) ConfigFunc {
+// AddRequestMiddleware wraps every request sent to Five9 with middleware. The first middleware added is the outermost.
+func AddRequestMiddleware(middleware ...RequestMiddleware) ConfigFunc {
+	return func(s *Service) {
+		s.agentService.authState.client.requestMiddleware = append(
+			s.agentService.authState.client.requestMiddleware,
+			middleware...,
+		)
+	}
+}
+
+// AddRequestObserver calls observers after every attempt of a REST call, including the login.
+func AddRequestObserver(observers ...func(RequestAttempt)) ConfigFunc {
+	return func(s *Service) {
+		s.agentService.authState.client.requestObservers = append(
+			s.agentService.authState.client.requestObservers,
+			observers...,
+		)
+	}
+}
+
+// SetLogger logs the login, retries and WebSocket activity to logger. Credentials and tokens are redacted.
+// By default nothing is logged.
+func SetLogger(logger *slog.Logger) ConfigFunc {
+	return func(s *Service) {
+		if logger == nil {
+			logger = newDiscardLogger()
+		}
+
+		s.agentService.authState.client.logger = logger
+	}
+}
+
+// SetTelemetry records OpenTelemetry spans and metrics for REST calls, the login and WebSocket frames.
+// A nil provider disables that signal, which is the default for both.
+func SetTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) ConfigFunc {
+	return func(s *Service) {
+		t := newTelemetry(tracerProvider, meterProvider)
+		t.observeCacheAge(s.supervisorService)
+
+		s.agentService.authState.client.telemetry.stopObservingCacheAge()
+		s.agentService.authState.client.telemetry = t
+	}
+}
+
+func SetWebsocketHandler(w WebSocketHandler) ConfigFunc {
 	return func(s *Service) {
 		s.supervisorService.webSocketHandler = w
 	}
 }
 
+// SetCredentialsProvider replaces the credentials passed to NewService with provider, which is asked for the
+// credentials every time the service logs in.
+func SetCredentialsProvider(provider CredentialsProvider) ConfigFunc {
+	return func(s *Service) {
+		s.agentService.authState.client.credentialsProvider = provider
+	}
+}
+
+// SetTokenProvider makes the service use the session of a Token from provider instead of logging in.
+// Use Token on another service to get a Token that can be shared.
+func SetTokenProvider(provider TokenProvider) ConfigFunc {
+	return func(s *Service) {
+		s.agentService.authState.client.tokenProvider = provider
+	}
+}
+
+// SetSessionStore saves the session after logging in, and resumes the stored session on the first login of a new
+// service if Five9 still accepts it. This avoids a new session, which takes over the live one, on every run.
+func SetSessionStore(store SessionStore) ConfigFunc {
+	return func(s *Service) {
+		s.agentService.authState.client.sessionStore = store
+	}
+}
+
+// SetLoginPolicy decides what happens when the user already has an active session, for example in another service.
+// five9types.PolicyForceIn (the default) takes over the existing session, five9types.PolicyAttachExisting joins it.
+func SetLoginPolicy(policy five9types.Policy) ConfigFunc {
+	return func(s *Service) {
+		s.agentService.authState.client.loginPolicy = policy
+	}
+}
+
+// SetLoginBaseURL changes the host used to log in, which defaults to LoginBaseURLUS.
+// Subsequent requests are sent to the data center returned by the login, see SetAPIServerStrategy.
+func SetLoginBaseURL(loginBaseURL string) ConfigFunc {
+	return func(s *Service) {
+		s.agentService.authState.client.loginBaseURL = strings.TrimSuffix(loginBaseURL, "/")
+	}
+}
+
+// SetMaintenanceNoticePolicy decides which maintenance notices are accepted while logging in. The default,
+// AcceptMaintenanceNotices, accepts every notice.
+func SetMaintenanceNoticePolicy(policy MaintenanceNoticePolicy) ConfigFunc {
+	return func(s *Service) {
+		if policy == nil {
+			policy = AcceptMaintenanceNotices
+		}
+
+		s.agentService.authState.client.maintenanceNoticePolicy = policy
+	}
+}
+
+// AddMaintenanceNoticeRecorder calls recorders with the outcome of every maintenance notice shown while logging in.
+func AddMaintenanceNoticeRecorder(recorders ...func(MaintenanceNoticeRecord)) ConfigFunc {
+	return func(s *Service) {
+		s.agentService.authState.client.maintenanceNoticeRecorders = append(
+			s.agentService.authState.client.maintenanceNoticeRecorders,
+			recorders...,
+		)
+	}
+}
+
+// SetAPIServerStrategy decides which of the API servers returned by a login are used, and in which order.
+// The default is ActiveDataCenters.
+func SetAPIServerStrategy(strategy APIServerStrategy) ConfigFunc {
+	return func(s *Service) {
+		s.agentService.authState.client.apiServerStrategy = strategy
+	}
+}
+
+// SetRateLimiter limits how fast, and how many at once, requests are sent to each API context. Pass the same
+// RateLimiter to several services to share its budgets. By default requests are not limited.
+func SetRateLimiter(limiter *RateLimiter) ConfigFunc {
+	return func(s *Service) {
+		s.agentService.authState.client.rateLimiter = limiter
+	}
+}
+
+// SetRetryPolicy controls how failed REST calls are retried. The default policy makes 3 attempts, see RetryPolicy.
+func SetRetryPolicy(policy RetryPolicy) ConfigFunc {
+	return func(s *Service) {
+		s.agentService.authState.client.retryPolicy = policy.withDefaults()
+	}
+}
+
 func SetRoundTripper(roundTripper http.RoundTripper) ConfigFunc {
 	return func(s *Service) {
 		s.agentService.authState.client.httpClient.Transport = roundTripper
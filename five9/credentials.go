@@ -0,0 +1,107 @@
+package five9
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+// CredentialsProvider supplies the credentials used to log in. It is called before every login, so rotated
+// credentials are picked up the next time the service logs in.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (five9types.PasswordCredentials, error)
+}
+
+// CredentialsProviderFunc adapts a function to a CredentialsProvider.
+type CredentialsProviderFunc func(ctx context.Context) (five9types.PasswordCredentials, error)
+
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (five9types.PasswordCredentials, error) {
+	return f(ctx)
+}
+
+// StaticCredentials always returns credentials. It is the provider used for the credentials passed to NewService.
+func StaticCredentials(credentials five9types.PasswordCredentials) CredentialsProvider {
+	return CredentialsProviderFunc(func(context.Context) (five9types.PasswordCredentials, error) {
+		return credentials, nil
+	})
+}
+
+// Token is a login made by another service or process, with the cookies of its session.
+type Token struct {
+	LoginResponse five9types.LoginResponse `json:"loginResponse"`
+	Cookies       []*http.Cookie           `json:"cookies"`
+}
+
+// TokenProvider supplies a Token to use instead of logging in, so several workers can share one Five9 session.
+// It is called whenever the service would otherwise log in, including after the session was migrated.
+type TokenProvider interface {
+	Token(ctx context.Context, apiContext APIContext) (Token, error)
+}
+
+// TokenProviderFunc adapts a function to a TokenProvider.
+type TokenProviderFunc func(ctx context.Context, apiContext APIContext) (Token, error)
+
+func (f TokenProviderFunc) Token(ctx context.Context, apiContext APIContext) (Token, error) {
+	return f(ctx, apiContext)
+}
+
+// Token returns the login of the supervisor service and the cookies of its session, logging in first if needed.
+// Pass it to another service with SetTokenProvider to share the session.
+func (s *SupervisorService) Token(ctx context.Context) (Token, error) {
+	return s.authState.token(ctx)
+}
+
+// Token returns the login of the agent service and the cookies of its session, logging in first if needed.
+// Pass it to another service with SetTokenProvider to share the session.
+func (s *AgentService) Token(ctx context.Context) (Token, error) {
+	return s.authState.token(ctx)
+}
+
+// token returns the current login with its session cookies, logging in first if needed.
+func (a *authenticationState) token(ctx context.Context) (Token, error) {
+	login, err := a.getLogin(ctx)
+	if err != nil {
+		return Token{}, err
+	}
+
+	return a.tokenFor(login), nil
+}
+
+// tokenFor returns login with the session cookies that are sent to its API server.
+func (a *authenticationState) tokenFor(login *five9types.LoginResponse) Token {
+	token := Token{
+		LoginResponse: *login,
+	}
+
+	if a.client.httpClient.Jar != nil {
+		token.Cookies = a.client.httpClient.Jar.Cookies(&url.URL{Scheme: "https", Host: a.apiHost(login)})
+	}
+
+	return token
+}
+
+// useToken stores the cookies of token for the login and API servers, so requests are sent with its session.
+func (a *authenticationState) useToken(token Token) {
+	if a.client.httpClient.Jar == nil {
+		return
+	}
+
+	loginURL, err := url.Parse(a.client.loginBaseURL)
+	if err == nil {
+		a.client.httpClient.Jar.SetCookies(loginURL, token.Cookies)
+	}
+
+	for _, apiHost := range append(a.client.apiServerStrategy(token.LoginResponse), token.LoginResponse.GetAPIHost()) {
+		a.client.httpClient.Jar.SetCookies(&url.URL{Scheme: "https", Host: apiHost}, token.Cookies)
+	}
+}
+
+// apiContext returns the API context the requests of the authentication state are sent to.
+func (a *authenticationState) apiContext() APIContext {
+	apiContext, _, _ := strings.Cut(a.apiContextPath, "/")
+
+	return APIContext(apiContext)
+}
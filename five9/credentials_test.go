@@ -0,0 +1,154 @@
+package five9_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9test"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+func Test_CredentialsProvider_RotatedPassword(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	credentials := five9types.PasswordCredentials{Username: "supervisor@example.com", Password: "first"}
+	server.SetCredentials(credentials)
+
+	mutex := &sync.Mutex{}
+	logins := 0
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(
+			server.ConfigFuncs(),
+			five9.SetCredentialsProvider(five9.CredentialsProviderFunc(
+				func(ctx context.Context) (five9types.PasswordCredentials, error) {
+					mutex.Lock()
+					defer mutex.Unlock()
+
+					logins++
+
+					return credentials, nil
+				},
+			)),
+		)...,
+	)
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rotate the password, and make the service log in again.
+	mutex.Lock()
+	credentials.Password = "second"
+	mutex.Unlock()
+
+	server.SetCredentials(credentials)
+	server.Migrate()
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if logins != 2 {
+		t.Fatalf("expected the provider to be asked for credentials at each of the 2 logins, got %d", logins)
+	}
+}
+
+func Test_CredentialsProvider_Error(t *testing.T) {
+	ctx := context.Background()
+
+	providerErr := errors.New("vault sealed")
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&MockRoundTripper{}),
+		five9.SetCredentialsProvider(five9.CredentialsProviderFunc(
+			func(ctx context.Context) (five9types.PasswordCredentials, error) {
+				return five9types.PasswordCredentials{}, providerErr
+			},
+		)),
+	)
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); !errors.Is(err, providerErr) {
+		t.Fatalf("expected the provider error, got %v", err)
+	}
+}
+
+func Test_TokenProvider_SharedSession(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	first := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+
+	token, err := first.Supervisor().Token(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(
+			server.ConfigFuncs(),
+			five9.SetTokenProvider(five9.TokenProviderFunc(
+				func(ctx context.Context, apiContext five9.APIContext) (five9.Token, error) {
+					if apiContext != five9.APIContextSupervisor {
+						t.Errorf("unexpected API context %s", apiContext)
+					}
+
+					return token, nil
+				},
+			)),
+		)...,
+	)
+
+	if _, err := second.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := first.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if logins := countRequests(server, "POST /supsvcs/rs/svc/auth/login"); logins != 1 {
+		t.Fatalf("expected both services to share a single login, got %d logins", logins)
+	}
+}
+
+func Test_TokenProvider_LogsInOtherService(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	first := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+
+	// The provider logs in the first service from inside the login of the second one.
+	second := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(
+			server.ConfigFuncs(),
+			five9.SetTokenProvider(five9.TokenProviderFunc(
+				func(ctx context.Context, _ five9.APIContext) (five9.Token, error) {
+					return first.Supervisor().Token(ctx)
+				},
+			)),
+		)...,
+	)
+
+	if _, err := second.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if logins := countRequests(server, "POST /supsvcs/rs/svc/auth/login"); logins != 1 {
+		t.Fatalf("expected both services to share a single login, got %d logins", logins)
+	}
+}
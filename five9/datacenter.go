@@ -0,0 +1,122 @@
+package five9
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+// Login hosts for the Five9 regions. Use them with SetLoginBaseURL.
+const (
+	LoginBaseURLUS = "https://app.five9.com"
+	LoginBaseURLEU = "https://app.eu.five9.com"
+	LoginBaseURLCA = "https://app.ca.five9.com"
+)
+
+// APIServerStrategy orders the API servers (host:port) returned by a login. Requests are sent to the first server,
+// and fail over to the next one when a server cannot be reached.
+type APIServerStrategy func(login five9types.LoginResponse) []string
+
+// ActiveDataCenters is the default APIServerStrategy. It uses the API servers of the active data centers,
+// in the order returned by Five9.
+func ActiveDataCenters(login five9types.LoginResponse) []string {
+	return login.GetAPIHosts()
+}
+
+// PreferDataCenter returns an APIServerStrategy that tries the API servers of the named data center first,
+// whether or not Five9 marked it as active, followed by the servers of the active data centers.
+func PreferDataCenter(name string) APIServerStrategy {
+	return func(login five9types.LoginResponse) []string {
+		hosts := []string{}
+
+		for _, dataCenter := range login.Metadata.DataCenters {
+			if dataCenter.Name == name {
+				hosts = append(hosts, dataCenter.GetAPIHosts()...)
+			}
+		}
+
+		for _, host := range login.GetAPIHosts() {
+			if !containsHost(hosts, host) {
+				hosts = append(hosts, host)
+			}
+		}
+
+		return hosts
+	}
+}
+
+func containsHost(hosts []string, host string) bool {
+	for _, h := range hosts {
+		if h == host {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apiHostList holds the API servers for a login, and which of them is currently in use.
+type apiHostList struct {
+	mutex   *sync.Mutex
+	hosts   []string
+	current int
+}
+
+func (l *apiHostList) reset(hosts []string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.hosts = hosts
+	l.current = 0
+}
+
+func (l *apiHostList) len() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return len(l.hosts)
+}
+
+// host returns the API server in use, or fallback if the login returned none.
+func (l *apiHostList) host(fallback string) string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if len(l.hosts) == 0 {
+		return fallback
+	}
+
+	return l.hosts[l.current]
+}
+
+// failover moves on from failedHost to the next API server. It returns false if there are no servers left to try,
+// in which case the next request starts again from the first server.
+// If another request has already moved on from failedHost, the list is left as it is.
+func (l *apiHostList) failover(failedHost string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.current >= len(l.hosts) || l.hosts[l.current] != failedHost {
+		return true
+	}
+
+	l.current++
+
+	if l.current == len(l.hosts) {
+		l.current = 0
+
+		return false
+	}
+
+	return true
+}
+
+// isUnreachable reports whether err means the connection to the server could not be made, so the request was never
+// sent and can safely be tried against another server.
+func isUnreachable(err error) bool {
+	opErr := &net.OpError{}
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
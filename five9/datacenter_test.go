@@ -0,0 +1,116 @@
+package five9_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9test"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+func Test_APIServerFailover_Success(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	server.SetDataCenters(five9test.DataCenter{
+		Name:     "Atlanta Data Center",
+		Active:   true,
+		APIHosts: []string{unreachableAddr(t), server.Addr()},
+	})
+	server.SetQueues(five9types.QueueInfo{ID: "1", Name: "Sales"})
+
+	s := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+
+	queues, err := s.Supervisor().GetAllQueues(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queues) != 1 {
+		t.Fatalf("expected 1 queue, got %d", len(queues))
+	}
+}
+
+func Test_PreferDataCenter_Success(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	server.SetDataCenters(
+		five9test.DataCenter{
+			Name:     "Atlanta Data Center",
+			Active:   true,
+			APIHosts: []string{unreachableAddr(t)},
+		},
+		five9test.DataCenter{
+			Name:     "Santa Clara Data Center",
+			Active:   false,
+			APIHosts: []string{server.Addr()},
+		},
+	)
+	server.SetQueues(five9types.QueueInfo{ID: "1", Name: "Sales"})
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(
+			server.ConfigFuncs(),
+			five9.SetAPIServerStrategy(five9.PreferDataCenter("Santa Clara Data Center")),
+		)...,
+	)
+
+	queues, err := s.Supervisor().GetAllQueues(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queues) != 1 {
+		t.Fatalf("expected 1 queue, got %d", len(queues))
+	}
+}
+
+func Test_PreferDataCenter_Order(t *testing.T) {
+	login := five9types.LoginResponse{}
+	if err := json.Unmarshal([]byte(`{
+		"metadata": {
+			"dataCenters": [
+				{"name": "Atlanta Data Center", "active": true, "apiUrls": [{"host": "app-atl.five9.com", "port": "443"}]},
+				{"name": "Santa Clara Data Center", "active": false, "apiUrls": [{"host": "app-scl.five9.com", "port": "443"}]},
+				{"name": "Denver Data Center", "active": true, "apiUrls": [{"host": "app-den.five9.com", "port": "443"}]}
+			]
+		}
+	}`), &login); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"app-scl.five9.com:443", "app-atl.five9.com:443", "app-den.five9.com:443"}
+
+	hosts := five9.PreferDataCenter("Santa Clara Data Center")(login)
+	if strings.Join(hosts, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected %v, got %v", expected, hosts)
+	}
+}
+
+// unreachableAddr returns an address that refuses connections.
+func unreachableAddr(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := listener.Addr().String()
+
+	if err := listener.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return addr
+}
@@ -4,6 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9/five9types"
 )
 
 type five9Error struct {
@@ -22,18 +27,53 @@ type errorContext struct {
 	ObjectID    string `json:"objectId"`
 }
 
+// Error is returned when Five9 reply with an error status. The exception details are filled in when the body is a
+// Five9 exception, otherwise Message holds the body as text.
+//
+// Use errors.Is with ErrUnauthorized, ErrForbidden, ErrNotFound, ErrRateLimited or ErrServiceMigrated to check for
+// common statuses, and ErrorCode to branch on the Five9 error.
 type Error struct {
-	StatusCode int    `json:"status_code"`
-	Body       []byte `json:"body"`
-	Message    string `json:"message"`
+	StatusCode  int           `json:"status_code"`
+	Body        []byte        `json:"body"`
+	Message     string        `json:"message"`
+	ErrorCode   int           `json:"error_code"`
+	Timestamp   time.Time     `json:"timestamp"`
+	ContextCode string        `json:"context_code"`
+	ObjectID    string        `json:"object_id"`
+	Method      string        `json:"method"`
+	URL         string        `json:"url"`
+	RetryAfter  time.Duration `json:"retry_after"` // Parsed from the Retry-After header, zero if it was not sent.
 }
 
 func (err *Error) Error() string {
-	if err.Message == "" {
-		return fmt.Sprintf("Five9 REST API Error, Status Code: %d", err.StatusCode)
+	message := err.Message
+	if message == "" {
+		message = fmt.Sprintf("Five9 REST API Error, Status Code: %d", err.StatusCode)
+	}
+
+	if err.Method == "" {
+		return message
+	}
+
+	return fmt.Sprintf("%s %s: %s", err.Method, err.URL, message)
+}
+
+// Is reports whether target is the sentinel error for the status code of err.
+func (err *Error) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return err.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return err.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return err.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return err.StatusCode == http.StatusTooManyRequests
+	case ErrServiceMigrated:
+		return err.StatusCode == 435
 	}
 
-	return err.Message
+	return false
 }
 
 func (err *Error) UnmarshalJSON(b []byte) error {
@@ -41,6 +81,13 @@ func (err *Error) UnmarshalJSON(b []byte) error {
 	if targetErr := json.Unmarshal(b, &target); targetErr == nil {
 		if target.Five9ExceptionDetail.Message != "" {
 			err.Message = target.Five9ExceptionDetail.Message
+			err.ErrorCode = target.Five9ExceptionDetail.ErrorCode
+			err.ContextCode = target.Five9ExceptionDetail.Context.ContextCode
+			err.ObjectID = target.Five9ExceptionDetail.Context.ObjectID
+
+			if target.Five9ExceptionDetail.Timestamp != 0 {
+				err.Timestamp = time.UnixMilli(int64(target.Five9ExceptionDetail.Timestamp))
+			}
 
 			return nil
 		}
@@ -53,4 +100,36 @@ var (
 	ErrUnknownUserID          error = errors.New("unknown userID provided")
 	ErrWebSocketCacheNotReady error = errors.New("webSocket cache is not ready")
 	ErrWebSocketCacheStale    error = errors.New("webSocket cache is stale")
+
+	ErrUnauthorized    error = errors.New("unauthorized")
+	ErrForbidden       error = errors.New("forbidden")
+	ErrNotFound        error = errors.New("not found")
+	ErrRateLimited     error = errors.New("rate limited")
+	ErrServiceMigrated error = errors.New("service has been migrated") // Status 435 that logging in again did not resolve.
+
+	ErrWebSocketMaxAttemptsReached  error = errors.New("webSocket reconnect attempts exhausted")
+	ErrSubscriberTooSlow            error = errors.New("webSocket subscriber could not keep up with events")
+	ErrWebSocketDuplicateConnection error = errors.New("webSocket closed by a duplicate connection for the same user")
+	ErrWebSocketClosed              error = errors.New("webSocket closed by logging out")
+
+	ErrMaintenanceNoticesNotAccepted error = errors.New("maintenance notices not accepted")
 )
+
+// MaintenanceNoticesError is returned when logging in fails because the MaintenanceNoticePolicy did not accept
+// some maintenance notices. It matches ErrMaintenanceNoticesNotAccepted with errors.Is.
+type MaintenanceNoticesError struct {
+	Notices []five9types.MaintenanceNoticeInfo
+}
+
+func (err *MaintenanceNoticesError) Error() string {
+	ids := make([]string, 0, len(err.Notices))
+	for _, notice := range err.Notices {
+		ids = append(ids, string(notice.ID))
+	}
+
+	return fmt.Sprintf("%s: %s", ErrMaintenanceNoticesNotAccepted, strings.Join(ids, ", "))
+}
+
+func (err *MaintenanceNoticesError) Is(target error) bool {
+	return target == ErrMaintenanceNoticesNotAccepted
+}
@@ -0,0 +1,118 @@
+package five9_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+func Test_Error_Five9Exception(t *testing.T) {
+	ctx := context.Background()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_404.json"),
+					StatusCode: http.StatusNotFound,
+				}, nil
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+	)
+
+	_, err := s.Supervisor().GetAllDomainUsers(ctx)
+	if !errors.Is(err, five9.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	five9Error := &five9.Error{}
+	if !errors.As(err, &five9Error) {
+		t.Fatalf("expected a *five9.Error, got %T", err)
+	}
+
+	expected := five9.Error{
+		StatusCode:  http.StatusNotFound,
+		Message:     "Organization not found",
+		ErrorCode:   9,
+		Timestamp:   time.UnixMilli(1698851947550),
+		ContextCode: "ORGANIZATION",
+		ObjectID:    "987654321",
+		Method:      http.MethodGet,
+		URL:         "https://app-abc.five9.com:443/supsvcs/rs/svc/orgs/987654321/users",
+	}
+
+	if five9Error.StatusCode != expected.StatusCode ||
+		five9Error.Message != expected.Message ||
+		five9Error.ErrorCode != expected.ErrorCode ||
+		!five9Error.Timestamp.Equal(expected.Timestamp) ||
+		five9Error.ContextCode != expected.ContextCode ||
+		five9Error.ObjectID != expected.ObjectID ||
+		five9Error.Method != expected.Method ||
+		five9Error.URL != expected.URL {
+		t.Fatalf("expected %+v, got %+v", expected, *five9Error)
+	}
+}
+
+func Test_Error_NonJSONBody(t *testing.T) {
+	ctx := context.Background()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       io.NopCloser(strings.NewReader("Access Denied\n")),
+					StatusCode: http.StatusForbidden,
+				}, nil
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+	)
+
+	_, err := s.Supervisor().GetAllDomainUsers(ctx)
+	if !errors.Is(err, five9.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+
+	five9Error := &five9.Error{}
+	if !errors.As(err, &five9Error) || five9Error.Message != "Access Denied" {
+		t.Fatalf("expected the body to be kept as the message, got %v", err)
+	}
+}
+
+func Test_Error_Is(t *testing.T) {
+	testCases := map[int]error{
+		http.StatusUnauthorized:    five9.ErrUnauthorized,
+		http.StatusForbidden:       five9.ErrForbidden,
+		http.StatusNotFound:        five9.ErrNotFound,
+		http.StatusTooManyRequests: five9.ErrRateLimited,
+		435:                        five9.ErrServiceMigrated,
+	}
+
+	for statusCode, expected := range testCases {
+		err := error(&five9.Error{StatusCode: statusCode})
+
+		for _, sentinel := range testCases {
+			if errors.Is(err, sentinel) != (sentinel == expected) {
+				t.Errorf("status %d: errors.Is(%v) = %t", statusCode, sentinel, !(sentinel == expected))
+			}
+		}
+	}
+}
@@ -0,0 +1,772 @@
+package five9test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+	"github.com/google/uuid"
+	"nhooyr.io/websocket"
+)
+
+const (
+	DefaultUserID         five9types.UserID         = "123456789"
+	DefaultOrganizationID five9types.OrganizationID = "987654321"
+)
+
+const sessionCookiePrefix = "five9test-session-"
+
+// Server is a fake Five9 REST API and supervisor WebSocket, backed by an httptest.Server.
+// It keeps the login state of every API context (supsvcs, appsvcs, strsvcs) separately, just like Five9:
+// a new login must select a station (and accept any maintenance notices) before any other endpoint can be used,
+// and endpoints called in the wrong login state reply with Status 435.
+//
+// Point a five9.Service at it with the ConfigFuncs method.
+type Server struct {
+	server *httptest.Server
+
+	mutex               *sync.Mutex
+	credentials         *five9types.PasswordCredentials
+	userID              five9types.UserID
+	organizationID      five9types.OrganizationID
+	sessions            map[string]*serverSession
+	migrated            map[string]bool
+	notices             []five9types.MaintenanceNoticeInfo
+	users               []five9types.AgentInfo
+	queues              []five9types.QueueInfo
+	campaigns           []five9types.CampaignInfo
+	logoutReasonCodes   []five9types.ReasonCodeInfo
+	notReadyReasonCodes []five9types.ReasonCodeInfo
+	statistics          []StatisticsSnapshot
+	dataCenters         []DataCenter
+	webSockets          map[*websocket.Conn]struct{}
+	requests            []string
+	logouts             []five9types.ReasonCodeID
+	messages            messageCounters
+}
+
+type serverSession struct {
+	token      string
+	loginState five9types.UserLoginState
+}
+
+// NewServer starts a fake Five9 server. Call Close when the server is no longer needed.
+func NewServer() *Server {
+	s := &Server{
+		mutex:          &sync.Mutex{},
+		userID:         DefaultUserID,
+		organizationID: DefaultOrganizationID,
+		sessions:       map[string]*serverSession{},
+		migrated:       map[string]bool{},
+		webSockets:     map[*websocket.Conn]struct{}{},
+		messages:       newMessageCounters(),
+	}
+
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// URL returns the base URL of the server, such as https://127.0.0.1:51234.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Addr returns the host:port the server is listening on.
+func (s *Server) Addr() string {
+	return s.server.Listener.Addr().String()
+}
+
+// Client returns an http.Client that trusts the server's certificate.
+func (s *Server) Client() *http.Client {
+	return s.server.Client()
+}
+
+// ConfigFuncs returns the configuration needed for a five9.Service to log in to, and send every request to, the server.
+func (s *Server) ConfigFuncs() []five9.ConfigFunc {
+	return []five9.ConfigFunc{
+		five9.SetLoginBaseURL(s.server.URL),
+		five9.SetRoundTripper(s.server.Client().Transport),
+	}
+}
+
+// Close closes every WebSocket connection and shuts the server down.
+func (s *Server) Close() {
+	s.DisconnectWebSockets()
+
+	s.server.Close()
+}
+
+// DisconnectWebSockets closes every open WebSocket connection, as a restart of a Five9 server would. Sessions are kept.
+func (s *Server) DisconnectWebSockets() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for conn := range s.webSockets {
+		conn.Close(websocket.StatusGoingAway, "server closed")
+	}
+}
+
+// SetCredentials makes the server reject logins that do not use credentials. By default any credentials are accepted.
+func (s *Server) SetCredentials(credentials five9types.PasswordCredentials) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.credentials = &credentials
+}
+
+// SetMaintenanceNotices sets the notices that must be accepted after the next session is started.
+func (s *Server) SetMaintenanceNotices(notices ...five9types.MaintenanceNoticeInfo) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.notices = notices
+}
+
+// MaintenanceNotices returns the current notices, including whether they have been accepted.
+func (s *Server) MaintenanceNotices() []five9types.MaintenanceNoticeInfo {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]five9types.MaintenanceNoticeInfo{}, s.notices...)
+}
+
+func (s *Server) SetUsers(users ...five9types.AgentInfo) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.users = users
+}
+
+func (s *Server) SetQueues(queues ...five9types.QueueInfo) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.queues = queues
+}
+
+func (s *Server) SetCampaigns(campaigns ...five9types.CampaignInfo) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.campaigns = campaigns
+}
+
+func (s *Server) SetReasonCodes(logoutReasonCodes, notReadyReasonCodes []five9types.ReasonCodeInfo) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.logoutReasonCodes = logoutReasonCodes
+	s.notReadyReasonCodes = notReadyReasonCodes
+}
+
+// SetStatistics sets the snapshot sent over the WebSocket (event 5000) when the client requests full statistics.
+func (s *Server) SetStatistics(snapshots ...StatisticsSnapshot) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.statistics = snapshots
+}
+
+// DataCenter is a data center returned by a login. APIHosts are formatted as host:port, see Server.Addr.
+type DataCenter struct {
+	Name     string
+	Active   bool
+	APIHosts []string
+}
+
+// SetDataCenters sets the data centers returned by a login. By default there is a single active data center whose
+// API server is the server itself.
+func (s *Server) SetDataCenters(dataCenters ...DataCenter) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.dataCenters = dataCenters
+}
+
+// Migrate simulates Five9 migrating the service: every existing session is dropped, so requests made with it
+// receive Status 435, and the next login is in the RELOGIN state.
+func (s *Server) Migrate() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for apiContext := range s.sessions {
+		s.migrated[apiContext] = true
+	}
+
+	s.sessions = map[string]*serverSession{}
+}
+
+// LoginState returns the login state of the session for an API context, such as "supsvcs".
+func (s *Server) LoginState(apiContext string) (five9types.UserLoginState, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	session, ok := s.sessions[apiContext]
+	if !ok {
+		return "", false
+	}
+
+	return session.loginState, true
+}
+
+// Logouts returns the reason code sent with each session logout, in order. The ID is empty for a logout without one.
+func (s *Server) Logouts() []five9types.ReasonCodeID {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]five9types.ReasonCodeID{}, s.logouts...)
+}
+
+// Requests returns every request received by the server, in order, formatted as "METHOD /path".
+func (s *Server) Requests() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]string{}, s.requests...)
+}
+
+// WebSocketConnections returns the number of open WebSocket connections.
+func (s *Server) WebSocketConnections() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.webSockets)
+}
+
+// SendEvent sends a frame for eventID with the given payload to every open WebSocket connection.
+// Each event gets the next message ID of the frames sent to this user only.
+func (s *Server) SendEvent(ctx context.Context, eventID five9types.EventID, payload any) error {
+	frame, err := NewFrame(five9types.WebsocketMessageContext{
+		EventID:     eventID,
+		EventReason: five9types.EventReasonUpdated,
+		MessageID:   s.nextMessageID(false),
+		UserID:      s.userID,
+	}, payload)
+	if err != nil {
+		return err
+	}
+
+	return s.broadcast(ctx, frame)
+}
+
+// SendIncrementalUpdate sends an incremental statistics update (event 5012) to every open WebSocket connection.
+// Each update gets the next broadcast message ID, so clients see an unbroken sequence.
+func (s *Server) SendIncrementalUpdate(ctx context.Context, updates ...IncrementalUpdate) error {
+	frame, err := NewFrame(five9types.WebsocketMessageContext{
+		EventID:     five9types.EventIDIncrementalStatsUpdate,
+		EventReason: five9types.EventReasonUpdated,
+		MessageID:   s.nextMessageID(true),
+		UserID:      s.userID,
+		BroadCast:   true,
+	}, updates)
+	if err != nil {
+		return err
+	}
+
+	return s.broadcast(ctx, frame)
+}
+
+func (s *Server) nextMessageID(broadcast bool) five9types.MessageID {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.messages.next(broadcast)
+}
+
+func (s *Server) broadcast(ctx context.Context, frame []byte) error {
+	s.mutex.Lock()
+	conns := make([]*websocket.Conn, 0, len(s.webSockets))
+	for conn := range s.webSockets {
+		conns = append(conns, conn)
+	}
+	s.mutex.Unlock()
+
+	for _, conn := range conns {
+		if err := conn.Write(ctx, websocket.MessageText, frame); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	s.requests = append(s.requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+	s.mutex.Unlock()
+
+	// Paths look like /supsvcs/rs/svc/supervisors/123/login_state, or /supsvcs/sws/<uuid> for the WebSocket.
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) < 2 {
+		writeError(w, http.StatusNotFound, "unknown endpoint")
+
+		return
+	}
+
+	apiContext := segments[0]
+
+	if apiContext == "supsvcs" && segments[1] == "sws" {
+		s.serveWebSocket(w, r)
+
+		return
+	}
+
+	if len(segments) < 4 || segments[1] != "rs" || segments[2] != "svc" {
+		writeError(w, http.StatusNotFound, "unknown endpoint")
+
+		return
+	}
+
+	route := segments[3:]
+
+	if r.Method == http.MethodPost && matchRoute(route, "auth", "login") {
+		s.serveLogin(w, r, apiContext)
+
+		return
+	}
+
+	session, ok := s.session(r, apiContext)
+	if !ok {
+		if s.isMigrated(apiContext) {
+			writeError(w, 435, "Service has been migrated")
+
+			return
+		}
+
+		writeError(w, http.StatusUnauthorized, "Session is not authenticated")
+
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && matchRoute(route, "auth", "metadata"):
+		writeJSON(w, http.StatusOK, s.loginResponse(session))
+
+		return
+	case r.Method == http.MethodPost && matchRoute(route, "auth", "logout"):
+		s.mutex.Lock()
+		delete(s.sessions, apiContext)
+		s.mutex.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	case len(route) >= 3 && (route[0] == "supervisors" || route[0] == "agents"):
+		if five9types.UserID(route[1]) != s.userID {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Unknown user %s", route[1]))
+
+			return
+		}
+
+		if s.serveUserSession(w, r, session, route[2:]) {
+			return
+		}
+	}
+
+	if loginState := s.sessionLoginState(session); loginState != five9types.UserLoginStateWorking {
+		writeError(w, 435, fmt.Sprintf("Improper login state %s. Allowed is WORKING", loginState))
+
+		return
+	}
+
+	switch {
+	case len(route) == 3 && route[0] == "orgs" && r.Method == http.MethodGet:
+		if five9types.OrganizationID(route[1]) != s.organizationID {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Unknown organization %s", route[1]))
+
+			return
+		}
+
+		s.serveOrganization(w, route[2])
+	case len(route) == 3 && route[0] == "supervisors" && route[2] == "request_full_statistics" && r.Method == http.MethodPut:
+		w.WriteHeader(http.StatusNoContent)
+
+		go s.sendStatistics()
+	default:
+		writeError(w, http.StatusNotFound, "unknown endpoint")
+	}
+}
+
+func (s *Server) serveLogin(w http.ResponseWriter, r *http.Request, apiContext string) {
+	payload := five9types.LoginPayload{}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.credentials != nil && *s.credentials != payload.PasswordCredentials {
+		writeError(w, http.StatusUnauthorized, "Invalid username or password")
+
+		return
+	}
+
+	session := &serverSession{
+		token:      uuid.NewString(),
+		loginState: five9types.UserLoginStateSelectStation,
+	}
+
+	if s.migrated[apiContext] {
+		session.loginState = five9types.UserLoginStateRelogin
+		delete(s.migrated, apiContext)
+	}
+
+	s.sessions[apiContext] = session
+
+	http.SetCookie(w, &http.Cookie{
+		Name:  sessionCookiePrefix + apiContext,
+		Value: session.token,
+		Path:  "/",
+	})
+
+	writeJSON(w, http.StatusOK, s.loginResponseLocked(session))
+}
+
+// serveUserSession handles the endpoints that can be used before the session is WORKING.
+// It returns false if route is not one of them.
+func (s *Server) serveUserSession(w http.ResponseWriter, r *http.Request, session *serverSession, route []string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && matchRoute(route, "login_state"):
+		writeJSON(w, http.StatusOK, session.loginState)
+	case r.Method == http.MethodPut && matchRoute(route, "session_start"):
+		if session.loginState != five9types.UserLoginStateSelectStation &&
+			session.loginState != five9types.UserLoginStateAcceptNotice {
+			writeError(w, 435, fmt.Sprintf("Improper login state %s. Allowed is SELECT_STATION", session.loginState))
+
+			return true
+		}
+
+		session.loginState = s.startedLoginStateLocked()
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut && matchRoute(route, "session_restart"):
+		if session.loginState != five9types.UserLoginStateRelogin {
+			writeError(w, 435, fmt.Sprintf("Improper login state %s. Allowed is RELOGIN", session.loginState))
+
+			return true
+		}
+
+		session.loginState = s.startedLoginStateLocked()
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut && matchRoute(route, "logout"):
+		payload := five9types.LogoutPayload{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+
+			return true
+		}
+
+		s.logouts = append(s.logouts, payload.ReasonCodeID)
+
+		// The session can be started again until the token is invalidated by auth/logout.
+		session.loginState = five9types.UserLoginStateSelectStation
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && matchRoute(route, "maintenance_notices"):
+		writeJSON(w, http.StatusOK, append([]five9types.MaintenanceNoticeInfo{}, s.notices...))
+	case r.Method == http.MethodPut && len(route) == 3 && route[0] == "maintenance_notices" && route[2] == "accept":
+		for i := range s.notices {
+			if s.notices[i].ID != five9types.MaintenanceNoticeID(route[1]) {
+				continue
+			}
+
+			s.notices[i].Accepted = true
+
+			if session.loginState == five9types.UserLoginStateAcceptNotice {
+				session.loginState = s.startedLoginStateLocked()
+			}
+
+			writeJSON(w, http.StatusOK, s.notices[i])
+
+			return true
+		}
+
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Unknown maintenance notice %s", route[1]))
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) serveOrganization(w http.ResponseWriter, resource string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch resource {
+	case "users":
+		writeJSON(w, http.StatusOK, nonNil(s.users))
+	case "skills":
+		writeJSON(w, http.StatusOK, nonNil(s.queues))
+	case "campaigns":
+		writeJSON(w, http.StatusOK, nonNil(s.campaigns))
+	case "logout_reason_codes":
+		writeJSON(w, http.StatusOK, nonNil(s.logoutReasonCodes))
+	case "not_ready_reason_codes":
+		writeJSON(w, http.StatusOK, nonNil(s.notReadyReasonCodes))
+	default:
+		writeError(w, http.StatusNotFound, "unknown endpoint")
+	}
+}
+
+func (s *Server) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.session(r, "supsvcs"); !ok {
+		writeError(w, http.StatusUnauthorized, "Session is not authenticated")
+
+		return
+	}
+
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	s.mutex.Lock()
+	s.webSockets[conn] = struct{}{}
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.webSockets, conn)
+		s.mutex.Unlock()
+	}()
+
+	ctx := r.Context()
+
+	connected, err := NewFrame(five9types.WebsocketMessageContext{
+		EventID:     five9types.EventIDServerConnected,
+		EventReason: five9types.EventReasonConnectionSuccessful,
+		UserID:      s.userID,
+	}, nil)
+	if err != nil {
+		return
+	}
+
+	if err := conn.Write(ctx, websocket.MessageText, connected); err != nil {
+		return
+	}
+
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+
+		if string(data) != "ping" {
+			continue
+		}
+
+		pong, err := NewFrame(five9types.WebsocketMessageContext{
+			EventID: five9types.EventIDPongReceived,
+			UserID:  s.userID,
+		}, "pong")
+		if err != nil {
+			return
+		}
+
+		if err := conn.Write(ctx, websocket.MessageText, pong); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) sendStatistics() {
+	s.mutex.Lock()
+	snapshots := append([]StatisticsSnapshot{}, s.statistics...)
+	s.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_ = s.SendEvent(ctx, five9types.EventIDSupervisorStats, snapshots)
+}
+
+func (s *Server) session(r *http.Request, apiContext string) (*serverSession, bool) {
+	cookie, err := r.Cookie(sessionCookiePrefix + apiContext)
+	if err != nil {
+		return nil, false
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	session, ok := s.sessions[apiContext]
+	if !ok || session.token != cookie.Value {
+		return nil, false
+	}
+
+	return session, true
+}
+
+func (s *Server) sessionLoginState(session *serverSession) five9types.UserLoginState {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return session.loginState
+}
+
+func (s *Server) isMigrated(apiContext string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.migrated[apiContext]
+}
+
+// startedLoginStateLocked is the state of a session once it has been started. The caller must hold the mutex.
+func (s *Server) startedLoginStateLocked() five9types.UserLoginState {
+	for _, notice := range s.notices {
+		if !notice.Accepted {
+			return five9types.UserLoginStateAcceptNotice
+		}
+	}
+
+	return five9types.UserLoginStateWorking
+}
+
+func (s *Server) loginResponse(session *serverSession) loginResponse {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.loginResponseLocked(session)
+}
+
+func (s *Server) loginResponseLocked(session *serverSession) loginResponse {
+	dataCenters := s.dataCenters
+	if len(dataCenters) == 0 {
+		dataCenters = []DataCenter{
+			{
+				Name:     "five9test",
+				Active:   true,
+				APIHosts: []string{s.Addr()},
+			},
+		}
+	}
+
+	response := loginResponse{
+		TokenID:   session.token,
+		SessionID: session.token,
+		OrgID:     s.organizationID,
+		UserID:    s.userID,
+		Metadata: loginMetadata{
+			FreedomURL:  s.server.URL,
+			DataCenters: []loginDataCenter{},
+		},
+	}
+
+	for _, dataCenter := range dataCenters {
+		servers := []loginServer{}
+
+		for _, apiHost := range dataCenter.APIHosts {
+			host, port, _ := net.SplitHostPort(apiHost)
+			servers = append(servers, loginServer{
+				Host:     host,
+				Port:     port,
+				RouteKey: "FIVE9TEST",
+				Version:  "13.0.0",
+			})
+		}
+
+		response.Metadata.DataCenters = append(response.Metadata.DataCenters, loginDataCenter{
+			Name:   dataCenter.Name,
+			UI:     servers,
+			API:    servers,
+			Login:  servers,
+			Active: dataCenter.Active,
+		})
+	}
+
+	return response
+}
+
+// loginResponse mirrors five9types.LoginResponse, whose server type is not exported.
+type loginResponse struct {
+	TokenID   string                    `json:"tokenId"`
+	SessionID string                    `json:"sessionId"`
+	OrgID     five9types.OrganizationID `json:"orgId"`
+	UserID    five9types.UserID         `json:"userId"`
+	Metadata  loginMetadata             `json:"metadata"`
+}
+
+type loginMetadata struct {
+	FreedomURL  string            `json:"freedomUrl"`
+	DataCenters []loginDataCenter `json:"dataCenters"`
+}
+
+type loginDataCenter struct {
+	Name   string        `json:"name"`
+	UI     []loginServer `json:"uiUrls"`
+	API    []loginServer `json:"apiUrls"`
+	Login  []loginServer `json:"loginUrls"`
+	Active bool          `json:"active"`
+}
+
+type loginServer struct {
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	RouteKey string `json:"routeKey"`
+	Version  string `json:"version"`
+}
+
+type exceptionDetail struct {
+	Five9ExceptionDetail struct {
+		Timestamp int64  `json:"timestamp"`
+		ErrorCode int    `json:"errorCode"`
+		Message   string `json:"message"`
+		Context   struct {
+			ContextCode string `json:"contextCode"`
+		} `json:"context"`
+	} `json:"five9ExceptionDetail"`
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	detail := exceptionDetail{}
+	detail.Five9ExceptionDetail.Timestamp = time.Now().UnixMilli()
+	detail.Five9ExceptionDetail.ErrorCode = 3
+	detail.Five9ExceptionDetail.Message = message
+	detail.Five9ExceptionDetail.Context.ContextCode = "LOGIN"
+
+	writeJSON(w, statusCode, detail)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func matchRoute(route []string, segments ...string) bool {
+	if len(route) != len(segments) {
+		return false
+	}
+
+	for i := range segments {
+		if route[i] != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+
+	return items
+}
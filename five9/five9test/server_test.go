@@ -0,0 +1,185 @@
+package five9test_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9test"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+func Test_Server_LoginAcceptsMaintenanceNotices(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	server.SetMaintenanceNotices(
+		five9types.MaintenanceNoticeInfo{ID: "8213", Annotation: "Service Update 9"},
+		five9types.MaintenanceNoticeInfo{ID: "8214", Annotation: "Service Update 10"},
+	)
+	server.SetUsers(five9types.AgentInfo{ID: "1001", UserName: "agent@example.com"})
+
+	s := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+
+	users, err := s.Supervisor().GetAllDomainUsers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 1 || users[0].UserName != "agent@example.com" {
+		t.Fatalf("unexpected users: %+v", users)
+	}
+
+	for _, notice := range server.MaintenanceNotices() {
+		if !notice.Accepted {
+			t.Fatalf("maintenance notice %s was not accepted", notice.ID)
+		}
+	}
+
+	if loginState, _ := server.LoginState("supsvcs"); loginState != five9types.UserLoginStateWorking {
+		t.Fatalf("expected login state %s, got %s", five9types.UserLoginStateWorking, loginState)
+	}
+}
+
+func Test_Server_RejectsInvalidCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	server.SetCredentials(five9types.PasswordCredentials{Username: "supervisor", Password: "secret"})
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{Username: "supervisor", Password: "wrong"},
+		server.ConfigFuncs()...,
+	)
+
+	_, err := s.Supervisor().GetAllDomainUsers(ctx)
+
+	five9Error := &five9.Error{}
+	if !errors.As(err, &five9Error) || five9Error.StatusCode != 401 {
+		t.Fatalf("expected a 401 error, got %v", err)
+	}
+}
+
+func Test_Server_MigrationRestartsSession(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	s := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+
+	if _, err := s.Supervisor().GetAllQueues(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	server.Migrate()
+
+	// The request receives a 435, logs in again, restarts the session and is replayed.
+	if _, err := s.Supervisor().GetAllQueues(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	sessionRestarted := false
+	for _, request := range server.Requests() {
+		if request == "PUT /supsvcs/rs/svc/supervisors/"+string(five9test.DefaultUserID)+"/session_restart" {
+			sessionRestarted = true
+		}
+	}
+
+	if !sessionRestarted {
+		t.Fatalf("expected the session to be restarted, requests: %v", server.Requests())
+	}
+
+	if loginState, _ := server.LoginState("supsvcs"); loginState != five9types.UserLoginStateWorking {
+		t.Fatalf("expected login state %s, got %s", five9types.UserLoginStateWorking, loginState)
+	}
+}
+
+func Test_Server_WebSocketStatistics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	server.SetUsers(five9types.AgentInfo{ID: "1001", UserName: "agent@example.com"})
+	server.SetStatistics(five9test.StatisticsSnapshot{
+		DataSource: five9types.DataSourceAgentState,
+		Data: []five9types.AgentState{
+			{ID: "1001", State: five9types.UserStateReady},
+		},
+	})
+
+	s := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+
+	subscription := s.Supervisor().Subscribe(10, five9.SlowSubscriberDrop)
+	defer subscription.Unsubscribe()
+
+	websocketErr := make(chan error, 1)
+	go func() {
+		websocketErr <- s.Supervisor().StartWebsocket(ctx)
+	}()
+
+	waitForEvent(t, subscription, websocketErr, five9.StatisticsSnapshotEvent{DataSource: five9types.DataSourceAgentState})
+
+	agentStates, err := s.Supervisor().WSAgentState(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if agentStates["agent@example.com"].State != five9types.UserStateReady {
+		t.Fatalf("unexpected agent states: %+v", agentStates)
+	}
+
+	if err := server.SendIncrementalUpdate(ctx, five9test.IncrementalUpdate{
+		DataSource: five9types.DataSourceAgentState,
+		Updated: []five9types.AgentState{
+			{ID: "1001", State: five9types.UserStateNotReady},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForEvent(t, subscription, websocketErr, five9.AgentStateEvent{
+		Action:  five9.WebSocketEventActionUpdated,
+		AgentID: "1001",
+		State:   five9types.AgentState{ID: "1001", State: five9types.UserStateNotReady},
+	})
+}
+
+func waitForEvent(t *testing.T, subscription *five9.WebSocketSubscription, websocketErr <-chan error, expected five9.WebSocketEvent) {
+	t.Helper()
+
+	for {
+		select {
+		case event := <-subscription.Events():
+			if sameEvent(event, expected) {
+				return
+			}
+		case err := <-websocketErr:
+			t.Fatalf("webSocket stopped: %v", err)
+		case <-time.After(time.Second * 5):
+			t.Fatalf("timed out waiting for %T", expected)
+		}
+	}
+}
+
+func sameEvent(event, expected five9.WebSocketEvent) bool {
+	switch expected := expected.(type) {
+	case five9.AgentStateEvent:
+		agentEvent, ok := event.(five9.AgentStateEvent)
+
+		return ok &&
+			agentEvent.Action == expected.Action &&
+			agentEvent.AgentID == expected.AgentID &&
+			agentEvent.State.State == expected.State.State
+	default:
+		return event == expected
+	}
+}
@@ -0,0 +1,265 @@
+// Package five9test provides fakes for testing code that uses the five9 package without connecting to Five9.
+package five9test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+var _ five9.WebSocketHandler = (*WebSocketHandler)(nil)
+
+var ErrWebSocketClosed = errors.New("five9test: webSocket is closed")
+
+// WebSocketHandler is an in-memory five9.WebSocketHandler. Frames sent with the Send methods are returned by Read,
+// one at a time, and every frame written by the client is recorded so it can be asserted on.
+// Pings written by the client are answered with a pong (event 1202).
+//
+// Use it with five9.SetWebsocketHandler.
+type WebSocketHandler struct {
+	mutex          *sync.Mutex
+	serverFrames   chan []byte
+	closed         chan struct{}
+	connectError   error
+	connectionURLs []string
+	clientFrames   [][]byte
+	messages       messageCounters
+}
+
+func NewWebSocketHandler() *WebSocketHandler {
+	closed := make(chan struct{})
+	close(closed)
+
+	return &WebSocketHandler{
+		mutex:        &sync.Mutex{},
+		serverFrames: make(chan []byte),
+		closed:       closed,
+		messages:     newMessageCounters(),
+	}
+}
+
+// SetConnectError makes every following Connect call fail with err. Pass nil to allow connections again.
+func (h *WebSocketHandler) SetConnectError(err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.connectError = err
+}
+
+func (h *WebSocketHandler) Connect(_ context.Context, connectionURL string, _ *http.Client) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.connectError != nil {
+		return h.connectError
+	}
+
+	h.connectionURLs = append(h.connectionURLs, connectionURL)
+	h.closed = make(chan struct{})
+
+	return nil
+}
+
+func (h *WebSocketHandler) Read(ctx context.Context) ([]byte, error) {
+	closed := h.closedChannel()
+
+	select {
+	case frame := <-h.serverFrames:
+		return frame, nil
+	case <-closed:
+		return nil, ErrWebSocketClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (h *WebSocketHandler) Write(ctx context.Context, data []byte) error {
+	select {
+	case <-h.closedChannel():
+		return ErrWebSocketClosed
+	default:
+	}
+
+	h.mutex.Lock()
+	h.clientFrames = append(h.clientFrames, data)
+	h.mutex.Unlock()
+
+	if string(data) == "ping" {
+		// Answer without blocking the writer, as Five9 would.
+		go func() {
+			_ = h.SendPong(ctx)
+		}()
+	}
+
+	return nil
+}
+
+func (h *WebSocketHandler) Close() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	select {
+	case <-h.closed:
+	default:
+		close(h.closed)
+	}
+}
+
+// ConnectionURLs returns the URL of every successful Connect call, in order.
+func (h *WebSocketHandler) ConnectionURLs() []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return append([]string{}, h.connectionURLs...)
+}
+
+// ClientFrames returns every frame written by the client, in order.
+func (h *WebSocketHandler) ClientFrames() [][]byte {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return append([][]byte{}, h.clientFrames...)
+}
+
+// SendFrame delivers a raw frame to the client. It blocks until the client reads the frame or the context is done,
+// so it can be called before the client has connected.
+func (h *WebSocketHandler) SendFrame(ctx context.Context, frame []byte) error {
+	select {
+	case h.serverFrames <- frame:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SendEvent builds a frame for eventID with the given payload and delivers it with SendFrame.
+// Each event gets the next message ID of the frames sent to this user only.
+func (h *WebSocketHandler) SendEvent(ctx context.Context, eventID five9types.EventID, payload any) error {
+	frame, err := NewFrame(five9types.WebsocketMessageContext{
+		EventID:     eventID,
+		EventReason: five9types.EventReasonUpdated,
+		MessageID:   h.nextMessageID(false),
+	}, payload)
+	if err != nil {
+		return err
+	}
+
+	return h.SendFrame(ctx, frame)
+}
+
+// SendServerConnected delivers the frame Five9 sends when the connection is established (event 1010).
+func (h *WebSocketHandler) SendServerConnected(ctx context.Context) error {
+	frame, err := NewFrame(five9types.WebsocketMessageContext{
+		EventID:     five9types.EventIDServerConnected,
+		EventReason: five9types.EventReasonConnectionSuccessful,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	return h.SendFrame(ctx, frame)
+}
+
+// SendPong delivers a pong (event 1202).
+func (h *WebSocketHandler) SendPong(ctx context.Context) error {
+	frame, err := NewFrame(five9types.WebsocketMessageContext{
+		EventID: five9types.EventIDPongReceived,
+	}, "pong")
+	if err != nil {
+		return err
+	}
+
+	return h.SendFrame(ctx, frame)
+}
+
+// SendStatistics delivers a full statistics snapshot (event 5000).
+func (h *WebSocketHandler) SendStatistics(ctx context.Context, snapshots ...StatisticsSnapshot) error {
+	return h.SendEvent(ctx, five9types.EventIDSupervisorStats, snapshots)
+}
+
+// SendIncrementalUpdate delivers an incremental statistics update (event 5012), which Five9 broadcasts.
+// Each update gets the next broadcast message ID, so the client sees an unbroken sequence.
+func (h *WebSocketHandler) SendIncrementalUpdate(ctx context.Context, updates ...IncrementalUpdate) error {
+	frame, err := NewFrame(five9types.WebsocketMessageContext{
+		EventID:     five9types.EventIDIncrementalStatsUpdate,
+		EventReason: five9types.EventReasonUpdated,
+		MessageID:   h.nextMessageID(true),
+		BroadCast:   true,
+	}, updates)
+	if err != nil {
+		return err
+	}
+
+	return h.SendFrame(ctx, frame)
+}
+
+func (h *WebSocketHandler) nextMessageID(broadcast bool) five9types.MessageID {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.messages.next(broadcast)
+}
+
+func (h *WebSocketHandler) closedChannel() chan struct{} {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.closed
+}
+
+// StatisticsSnapshot is a single data source within a full statistics frame (event 5000).
+type StatisticsSnapshot struct {
+	DataSource five9types.DataSource `json:"dataSource"`
+	Data       any                   `json:"data"`
+}
+
+// IncrementalUpdate is a single data source within an incremental statistics frame (event 5012).
+// Added and Updated hold slices of the data source type, such as []five9types.AgentState, and Removed a slice of IDs.
+type IncrementalUpdate struct {
+	DataSource five9types.DataSource `json:"dataSource"`
+	Added      any                   `json:"added"`
+	Updated    any                   `json:"updated"`
+	Removed    any                   `json:"removed"`
+}
+
+// messageCounters numbers frames as Five9 does, counting broadcasts separately from the frames sent to a single user.
+// The counters start at the message IDs of the frames recorded in five9/test/webSocketFrames.
+type messageCounters struct {
+	user      uint64
+	broadcast uint64
+}
+
+func newMessageCounters() messageCounters {
+	return messageCounters{user: 15640, broadcast: 210976}
+}
+
+func (c *messageCounters) next(broadcast bool) five9types.MessageID {
+	if broadcast {
+		c.broadcast++
+
+		return five9types.MessageID(fmt.Sprintf("%d:3:4:5:149:1115", c.broadcast))
+	}
+
+	c.user++
+
+	return five9types.MessageID(fmt.Sprintf("%d:3:4:5:300000000000004:300000000000154", c.user))
+}
+
+// NewFrame encodes a WebSocket frame in the format used by Five9. A zero TimeStamp is set to the current time.
+func NewFrame(messageContext five9types.WebsocketMessageContext, payload any) ([]byte, error) {
+	if messageContext.TimeStamp == 0 {
+		messageContext.TimeStamp = uint64(time.Now().UnixMilli())
+	}
+
+	return json.Marshal(five9types.WebsocketMessage{
+		Context: messageContext,
+		Payload: payload,
+	})
+}
@@ -0,0 +1,7 @@
+package five9types
+
+type CampaignInfo struct {
+	ID   CampaignID `json:"id"`
+	Name string     `json:"name"`
+	Type string     `json:"type"`
+}
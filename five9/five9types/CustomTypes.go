@@ -1,10 +1,40 @@
 package five9types
 
+import (
+	"strconv"
+	"strings"
+)
+
 type WebsocketMessage struct {
 	Context WebsocketMessageContext `json:"context"`
 	Payload any                     `json:"payLoad"`
 }
 
 type WebsocketMessageContext struct {
-	EventID EventID `json:"eventId"`
+	EventID       EventID       `json:"eventId"`
+	EventReason   EventReason   `json:"eventReason"`
+	MessageID     MessageID     `json:"messageId"`
+	UserID        UserID        `json:"userId"`
+	UserName      UserName      `json:"userName"`
+	CorrelationID CorrelationID `json:"correlationId"`
+	TimeStamp     uint64        `json:"timeStamp"`
+	TenantID      TenantID      `json:"tenantId"`
+	BroadCast     bool          `json:"broadCast"`
+}
+
+// Sequence returns the message counter, the first segment of a message ID such as "210977:3:4:5:149:1115".
+// Broadcast frames and the frames sent to a single user are counted separately.
+// The second return value is false when the message has no ID, or the ID is not in the expected format.
+func (id MessageID) Sequence() (uint64, bool) {
+	counter, _, _ := strings.Cut(string(id), ":")
+	if counter == "" {
+		return 0, false
+	}
+
+	sequence, err := strconv.ParseUint(counter, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return sequence, true
 }
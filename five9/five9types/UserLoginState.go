@@ -53,6 +53,48 @@ type WebSocketIncrementalACDStateData struct {
 	Removed    []QueueID  `json:"removed"`
 }
 
+type WebSocketIncrementalCampaignStateData struct {
+	DataSource DataSource                             `json:"dataSource"`
+	Added      []WebSocketStatisticsCampaignStateData `json:"added"`
+	Updated    []WebSocketStatisticsCampaignStateData `json:"updated"`
+	Removed    []CampaignID                           `json:"removed"`
+}
+
+type WebSocketIncrementalInboundCampaignStatisticsData struct {
+	DataSource DataSource                                         `json:"dataSource"`
+	Added      []WebSocketStatisticsInboundCampaignStatisticsData `json:"added"`
+	Updated    []WebSocketStatisticsInboundCampaignStatisticsData `json:"updated"`
+	Removed    []CampaignID                                       `json:"removed"`
+}
+
+type WebSocketIncrementalOutboundCampaignStatisticsData struct {
+	DataSource DataSource                                          `json:"dataSource"`
+	Added      []WebSocketStatisticsOutboundCampaignStatisticsData `json:"added"`
+	Updated    []WebSocketStatisticsOutboundCampaignStatisticsData `json:"updated"`
+	Removed    []CampaignID                                        `json:"removed"`
+}
+
+type WebSocketIncrementalOutboundCampaignManagerData struct {
+	DataSource DataSource                                       `json:"dataSource"`
+	Added      []WebSocketStatisticsOutboundCampaignManagerData `json:"added"`
+	Updated    []WebSocketStatisticsOutboundCampaignManagerData `json:"updated"`
+	Removed    []CampaignID                                     `json:"removed"`
+}
+
+type WebSocketIncrementalUserSessionData struct {
+	DataSource DataSource                           `json:"dataSource"`
+	Added      []WebSocketStatisticsUserSessionData `json:"added"`
+	Updated    []WebSocketStatisticsUserSessionData `json:"updated"`
+	Removed    []SessionID                          `json:"removed"`
+}
+
+type WebSocketIncrementalStationData struct {
+	DataSource DataSource                       `json:"dataSource"`
+	Added      []WebSocketStatisticsStationData `json:"added"`
+	Updated    []WebSocketStatisticsStationData `json:"updated"`
+	Removed    []StationID                      `json:"removed"`
+}
+
 type WebSocketStatisticsAgentStateData struct {
 	ID                         UserID                   `json:"id"`
 	CallType                   any                      `json:"callType"`
@@ -97,6 +139,19 @@ type WebSocketStatisticsUserSessionData struct {
 	Station      StationID `json:"station"`
 }
 
+type WebSocketStatisticsStationData struct {
+	ID          StationID `json:"id"`
+	StationType string    `json:"stationType"`
+	UserID      UserID    `json:"userId"`
+}
+
+// UserSessionInfo is a live user session joined with the domain user and the station the session is using.
+type UserSessionInfo struct {
+	Session WebSocketStatisticsUserSessionData `json:"session"`
+	User    AgentInfo                          `json:"user"`
+	Station *WebSocketStatisticsStationData    `json:"station"`
+}
+
 type ACDState struct {
 	ID                      QueueID `json:"id"`
 	CallsInQueue            uint64  `json:"callsInQueue"`
@@ -175,6 +230,23 @@ type AgentStatistics struct {
 	Utilization                     float64           `json:"utilization"`
 }
 
+type WebSocketStatisticsOutboundCampaignStatisticsData struct {
+	ID                             CampaignID        `json:"id"`
+	AssociatedWithAgentsCallsCount uint64            `json:"associatedWithAgentsCallsCount"`
+	AbandonCallRate                float64           `json:"abandonCallRate"`
+	TotalCallsCount                uint64            `json:"totalCallsCount"`
+	AverageCallTime                uint64            `json:"averageCallTime"`
+	HandledCallsCount              uint64            `json:"handledCallsCount"`
+	AverageHandleTime              uint64            `json:"averageHandleTime"`
+	AverageWrapTime                uint64            `json:"averageWrapTime"`
+	CallCharges                    float64           `json:"callCharges"`
+	AbandonedCallsCount            uint64            `json:"abandonedCallsCount"`
+	ConnectedCallsCount            uint64            `json:"connectedCallsCount"`
+	Dispositions                   map[string]uint64 `json:"dispositions"`
+	FirstCallResolution            uint64            `json:"firstCallResolution"`
+	LongestHoldTime                uint64            `json:"longestHoldTime"`
+}
+
 type WebSocketStatisticsOutboundCampaignManagerData struct {
 	ID                                CampaignID         `json:"id"`
 	ReadyForCallAgentsCount           uint64             `json:"readyForCallAgentsCount"`
@@ -271,6 +343,30 @@ type WebsocketSupervisorACDData struct {
 	Data []ACDState `json:"data"`
 }
 
+type WebsocketSupervisorCampaignStateData struct {
+	Data []WebSocketStatisticsCampaignStateData `json:"data"`
+}
+
+type WebsocketSupervisorInboundCampaignStatisticsData struct {
+	Data []WebSocketStatisticsInboundCampaignStatisticsData `json:"data"`
+}
+
+type WebsocketSupervisorOutboundCampaignStatisticsData struct {
+	Data []WebSocketStatisticsOutboundCampaignStatisticsData `json:"data"`
+}
+
+type WebsocketSupervisorOutboundCampaignManagerData struct {
+	Data []WebSocketStatisticsOutboundCampaignManagerData `json:"data"`
+}
+
+type WebsocketSupervisorUserSessionData struct {
+	Data []WebSocketStatisticsUserSessionData `json:"data"`
+}
+
+type WebsocketSupervisorStationData struct {
+	Data []WebSocketStatisticsStationData `json:"data"`
+}
+
 type AgentState struct {
 	ID                         UserID                   `json:"id"`
 	CallType                   any                      `json:"callType"`
@@ -320,17 +416,37 @@ type LoginResponse struct {
 }
 
 func (v LoginResponse) GetAPIHost() string {
+	for _, host := range v.GetAPIHosts() {
+		return host
+	}
+
+	return "app.five9.com:443"
+}
+
+// GetAPIHosts returns the API servers of every active data center, in the order returned by Five9.
+func (v LoginResponse) GetAPIHosts() []string {
+	hosts := []string{}
+
 	for _, dataCenter := range v.Metadata.DataCenters {
 		if !dataCenter.Active {
 			continue
 		}
 
-		for _, server := range dataCenter.API {
-			return fmt.Sprintf("%s:%s", server.Host, server.Port)
-		}
+		hosts = append(hosts, dataCenter.GetAPIHosts()...)
 	}
 
-	return "app.five9.com:443"
+	return hosts
+}
+
+// GetAPIHosts returns the API servers of the data center, formatted as host:port.
+func (v DataCenter) GetAPIHosts() []string {
+	hosts := []string{}
+
+	for _, server := range v.API {
+		hosts = append(hosts, fmt.Sprintf("%s:%s", server.Host, server.Port))
+	}
+
+	return hosts
 }
 
 type LoginPayload struct {
@@ -338,3 +454,7 @@ type LoginPayload struct {
 	AppKey              string              `json:"appKey"`
 	Policy              Policy              `json:"policy"`
 }
+
+type LogoutPayload struct {
+	ReasonCodeID ReasonCodeID `json:"reasonCodeId,omitempty"`
+}
@@ -7,3 +7,31 @@ type UserFullStateInfo struct {
 	PendingState          Presence `json:"pendingState"`
 	PendingStateDelayTime uint64   `json:"pendingStateDelayTime"`
 }
+
+// UserStateUpdate is the payload used by a supervisor to change the presence of an agent.
+// Leave CurrentState or PendingState nil to keep the existing value.
+type UserStateUpdate struct {
+	CurrentState   *State `json:"currentState,omitempty"`
+	PendingState   *State `json:"pendingState,omitempty"`
+	GracefulModeOn bool   `json:"gracefulModeOn"`
+}
+
+// ReadyState returns a State that marks the agent as Ready on the provided channels.
+func ReadyState(channels ...Channel) *State {
+	readyChannels := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		readyChannels = append(readyChannels, string(channel))
+	}
+
+	return &State{
+		ReadyChannels: readyChannels,
+	}
+}
+
+// NotReadyState returns a State that marks the agent as Not Ready with the provided reason code.
+func NotReadyState(reasonCode NotReadyReasonCode) *State {
+	return &State{
+		ReadyChannels:      []string{},
+		NotReadyReasonCode: reasonCode,
+	}
+}
@@ -0,0 +1,36 @@
+package five9types
+
+import "log/slog"
+
+const redacted = "[REDACTED]"
+
+// LogValue keeps the password out of logs.
+func (v PasswordCredentials) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("username", v.Username),
+		slog.String("password", redacted),
+	)
+}
+
+// LogValue keeps the token out of logs.
+func (v AuthenticationTokenID) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// LogValue keeps the token and session ID out of logs.
+func (v LoginResponse) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("user_id", string(v.UserID)),
+		slog.String("org_id", string(v.OrgID)),
+		slog.String("api_host", v.GetAPIHost()),
+	)
+}
+
+// LogValue keeps the password out of logs.
+func (v LoginPayload) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("credentials", v.PasswordCredentials),
+		slog.String("app_key", v.AppKey),
+		slog.String("policy", string(v.Policy)),
+	)
+}
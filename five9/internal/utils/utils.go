@@ -17,6 +17,7 @@ type MemoryCacheInstance[Key comparable, T any] struct {
 	lastUpdated *time.Time
 	maxAge      *time.Duration
 	items       map[Key]T
+	generation  uint64
 }
 
 func NewMemoryCacheInstance[Key comparable, T any](maxAllowedAge *time.Duration) *MemoryCacheInstance[Key, T] {
@@ -38,10 +39,37 @@ func (cache *MemoryCacheInstance[Key, T]) Replace(freshData map[Key]T) {
 	cache.lastUpdated = &replaceTime
 }
 
+// ReplaceIfGeneration replaces the items like Replace, unless the cache has been reset since generation was read.
+// It returns false if the items were dropped.
+func (cache *MemoryCacheInstance[Key, T]) ReplaceIfGeneration(generation uint64, freshData map[Key]T) bool {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if cache.generation != generation {
+		return false
+	}
+
+	replaceTime := time.Now()
+
+	cache.items = freshData
+	cache.lastUpdated = &replaceTime
+
+	return true
+}
+
+// Generation changes each time the cache is reset, so data fetched before a reset can be told apart from newer data.
+func (cache *MemoryCacheInstance[Key, T]) Generation() uint64 {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return cache.generation
+}
+
 func (cache *MemoryCacheInstance[Key, T]) Reset() {
 	cache.mutex.Lock()
 	defer cache.mutex.Unlock()
 
+	cache.generation++
 	cache.lastUpdated = nil
 
 	cache.items = map[Key]T{}
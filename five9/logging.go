@@ -0,0 +1,18 @@
+package five9
+
+import (
+	"context"
+	"log/slog"
+)
+
+// discardHandler drops every record. It is the default, so the library is silent unless SetLogger is used.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+func newDiscardLogger() *slog.Logger {
+	return slog.New(discardHandler{})
+}
@@ -0,0 +1,94 @@
+package five9_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+func Test_SetLogger_RedactsCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       http.NoBody,
+					StatusCode: http.StatusServiceUnavailable,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+		),
+	}
+
+	buffer := &bytes.Buffer{}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{
+			Username: "supervisor@example.com",
+			Password: "hunter2",
+		},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRetryPolicy(five9.RetryPolicy{
+			InitialBackoff: time.Millisecond,
+		}),
+		five9.SetLogger(slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		}))),
+	)
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	output := buffer.String()
+
+	for _, secret := range []string{"hunter2", "a7da20a5-881b-00ii-1234-830384a1d2b0", "b309dd165e8b194eab1264d5ceecd13e36cbb1a5ec86c869c7fbec98f2352123"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("expected %q to be redacted from the logs", secret)
+		}
+	}
+
+	records := map[string]map[string]any{}
+
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		record := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatal(err)
+		}
+
+		records[record["msg"].(string)] = record
+	}
+
+	loggingIn, ok := records["five9 logging in"]
+	if !ok {
+		t.Fatal("expected the login to be logged")
+	}
+
+	credentials, _ := loggingIn["credentials"].(map[string]any)
+	if credentials["username"] != "supervisor@example.com" || credentials["password"] != "[REDACTED]" {
+		t.Errorf("expected the username and a redacted password, got %v", loggingIn["credentials"])
+	}
+
+	if record := records["five9 login state"]; record["user_id"] != "123456789" {
+		t.Errorf("expected the user ID to be logged, got %v", record)
+	}
+
+	if record := records["five9 request failed, retrying"]; record["attempt"] != float64(1) {
+		t.Errorf("expected the failed attempt to be logged, got %v", record)
+	}
+}
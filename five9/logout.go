@@ -0,0 +1,130 @@
+package five9
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+// Logout ends the sessions of every service, without a logout reason code.
+func (s *Service) Logout(ctx context.Context) error {
+	return errors.Join(
+		s.supervisorService.Logout(ctx, ""),
+		s.agentService.Logout(ctx, ""),
+		s.statisticsService.authState.logout(ctx, ""),
+	)
+}
+
+// Close ends the sessions of every service. It is the same as Logout.
+func (s *Service) Close(ctx context.Context) error {
+	return s.Logout(ctx)
+}
+
+// Logout ends the supervisor session with an optional logout reason code, such as the ID of one of the
+// ReasonCodeInfo returned by GetAllReasonCodes. Any open WebSocket is closed, RunWebsocket returns
+// ErrWebSocketClosed instead of reconnecting, and the cached data and stored session are dropped. The next
+// request logs in again, and the WebSocket can be started again.
+func (s *SupervisorService) Logout(ctx context.Context, reasonCodeID five9types.ReasonCodeID) error {
+	err := s.authState.logout(ctx, reasonCodeID)
+
+	s.webSocketMutex.Lock()
+	s.webSocketClosed = true
+	if s.webSocketCancel != nil {
+		s.webSocketCancel(ErrWebSocketClosed)
+		s.webSocketCancel = nil
+	}
+	s.webSocketMutex.Unlock()
+
+	s.resetCache()
+
+	return err
+}
+
+// Logout ends the agent session with an optional logout reason code, such as the ID of one of the
+// ReasonCodeInfo returned by GetAllReasonCodes. The stored session is dropped, and the next request logs in again.
+func (s *AgentService) Logout(ctx context.Context, reasonCodeID five9types.ReasonCodeID) error {
+	return s.authState.logout(ctx, reasonCodeID)
+}
+
+// logout ends the session of the current login, or else of the stored session. Nothing is sent to Five9 if
+// there is neither, as logging in just to log out would take over a session that may be in use elsewhere.
+func (a *authenticationState) logout(ctx context.Context, reasonCodeID five9types.ReasonCodeID) error {
+	a.loginMutex.Lock()
+	defer a.loginMutex.Unlock()
+
+	login := a.loginResponse
+	a.loginResponse = nil
+
+	if login == nil {
+		if token, ok := a.loadSession(ctx); ok {
+			login = &token.LoginResponse
+		}
+	}
+
+	// The stored session is about to end, so it must not be resumed by the next login.
+	a.sessionRestoreAttempted = true
+	a.deleteSession(ctx)
+
+	if login == nil {
+		return nil
+	}
+
+	a.client.logger.InfoContext(ctx, "five9 logging out",
+		"api_context", a.apiContextPath,
+		"user_id", login.UserID,
+		"reason_code_id", reasonCodeID,
+	)
+
+	return errors.Join(
+		a.endpointLogout(ctx, login, reasonCodeID),
+		a.endpointAuthLogout(ctx, login),
+	)
+}
+
+// endpointLogout ends the agent or supervisor session.
+func (a *authenticationState) endpointLogout(
+	ctx context.Context,
+	login *five9types.LoginResponse,
+	reasonCodeID five9types.ReasonCodeID,
+) error {
+	path := agentAPIPath
+	if a.apiContextPath == supervisorAPIContextPath {
+		path = supervisorAPIPath
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		fmt.Sprintf(
+			"/%s/%s/:userID/logout",
+			a.apiContextPath,
+			path,
+		),
+		structToReaderCloser(five9types.LogoutPayload{
+			ReasonCodeID: reasonCodeID,
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	return a.requestWithLogin(request, login, nil)
+}
+
+// endpointAuthLogout invalidates the token of the login.
+func (a *authenticationState) endpointAuthLogout(ctx context.Context, login *five9types.LoginResponse) error {
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/%s/auth/logout", a.apiContextPath),
+		http.NoBody,
+	)
+	if err != nil {
+		return err
+	}
+
+	return a.requestWithLogin(request, login, nil)
+}
@@ -0,0 +1,213 @@
+package five9_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9test"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+func Test_SupervisorLogout_ReasonCode(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	s := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Supervisor().Logout(ctx, "42"); err != nil {
+		t.Fatal(err)
+	}
+
+	if logouts := server.Logouts(); len(logouts) != 1 || logouts[0] != "42" {
+		t.Fatalf("expected a logout with reason code 42, got %v", logouts)
+	}
+
+	if _, ok := server.LoginState("supsvcs"); ok {
+		t.Fatal("expected the session to be ended")
+	}
+
+	// The next request logs in again.
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if logins := countRequests(server, "POST /supsvcs/rs/svc/auth/login"); logins != 2 {
+		t.Fatalf("expected to log in again after logging out, got %d logins", logins)
+	}
+}
+
+func Test_SupervisorLogout_ClosesWebSocket(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	s := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+
+	resynced := make(chan struct{}, 1)
+	runnerErr := make(chan error, 1)
+
+	go func() {
+		runnerErr <- s.Supervisor().RunWebsocket(ctx, five9.WebsocketRunnerConfig{
+			OnResynced: func() {
+				resynced <- struct{}{}
+			},
+		})
+	}()
+
+	select {
+	case <-resynced:
+	case err := <-runnerErr:
+		t.Fatal(err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for the WebSocket")
+	}
+
+	if err := s.Supervisor().Logout(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-runnerErr:
+		if !errors.Is(err, five9.ErrWebSocketClosed) {
+			t.Fatalf("expected ErrWebSocketClosed, got %v", err)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for the WebSocket to close")
+	}
+
+	if logins := countRequests(server, "POST /supsvcs/rs/svc/auth/login"); logins != 1 {
+		t.Fatalf("expected the runner not to log in again, got %d logins", logins)
+	}
+}
+
+func Test_SupervisorLogout_DuringReconnectBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	s := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+
+	resynced := make(chan struct{}, 1)
+	disconnected := make(chan struct{}, 1)
+	runnerErr := make(chan error, 1)
+
+	go func() {
+		runnerErr <- s.Supervisor().RunWebsocket(ctx, five9.WebsocketRunnerConfig{
+			InitialBackoff: time.Millisecond * 200,
+			OnResynced: func() {
+				resynced <- struct{}{}
+			},
+			OnDisconnected: func(error) {
+				disconnected <- struct{}{}
+			},
+		})
+	}()
+
+	select {
+	case <-resynced:
+	case err := <-runnerErr:
+		t.Fatal(err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for the WebSocket")
+	}
+
+	server.DisconnectWebSockets()
+
+	select {
+	case <-disconnected:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for the WebSocket to disconnect")
+	}
+
+	// The runner is waiting to reconnect, so there is no connection to close.
+	if err := s.Supervisor().Logout(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-runnerErr:
+		if !errors.Is(err, five9.ErrWebSocketClosed) {
+			t.Fatalf("expected ErrWebSocketClosed, got %v", err)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for the runner to return")
+	}
+
+	if logins := countRequests(server, "POST /supsvcs/rs/svc/auth/login"); logins != 1 {
+		t.Fatalf("expected the runner not to log in again, got %d logins", logins)
+	}
+}
+
+func Test_ServiceClose_NotLoggedIn(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	s := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+
+	if err := s.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Fatalf("expected nothing to be sent without a login, got %v", requests)
+	}
+}
+
+func Test_ServiceClose_StoredSession(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	store := five9.NewFileSessionStore(t.TempDir())
+
+	first := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(server.ConfigFuncs(), five9.SetSessionStore(store))...,
+	)
+
+	if _, err := first.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new process with the same store ends the stored session without logging in.
+	second := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(server.ConfigFuncs(), five9.SetSessionStore(store))...,
+	)
+
+	if err := second.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if logins := countRequests(server, "POST /supsvcs/rs/svc/auth/login"); logins != 1 {
+		t.Fatalf("expected not to log in to log out, got %d logins", logins)
+	}
+
+	if logouts := countRequests(server, "POST /supsvcs/rs/svc/auth/logout"); logouts != 1 {
+		t.Fatalf("expected the stored session to be logged out, got %d logouts", logouts)
+	}
+
+	if _, ok := server.LoginState("supsvcs"); ok {
+		t.Fatal("expected the session to be ended")
+	}
+
+	if _, ok, err := store.Load(ctx, five9.APIContextSupervisor); err != nil || ok {
+		t.Fatalf("expected the stored session to be deleted, got %t, %v", ok, err)
+	}
+}
@@ -0,0 +1,79 @@
+package five9
+
+import (
+	"context"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+// MaintenanceNoticePolicy decides whether a maintenance notice shown while logging in is accepted. A notice that is
+// not accepted fails the login with a *MaintenanceNoticesError, and returning an error fails the login with it.
+type MaintenanceNoticePolicy func(ctx context.Context, notice five9types.MaintenanceNoticeInfo) (bool, error)
+
+// AcceptMaintenanceNotices accepts every notice. It is the default policy.
+func AcceptMaintenanceNotices(context.Context, five9types.MaintenanceNoticeInfo) (bool, error) {
+	return true, nil
+}
+
+// RejectMaintenanceNotices accepts no notices, so logging in fails until they have been accepted elsewhere,
+// for example by a person in the Five9 web application.
+func RejectMaintenanceNotices(context.Context, five9types.MaintenanceNoticeInfo) (bool, error) {
+	return false, nil
+}
+
+// MaintenanceNoticeRecord is the outcome of a maintenance notice shown while logging in, for auditing.
+type MaintenanceNoticeRecord struct {
+	Time       time.Time
+	APIContext APIContext
+	UserID     five9types.UserID
+	Notice     five9types.MaintenanceNoticeInfo
+	Accepted   bool  // The notice has been accepted, by the policy or previously.
+	Err        error // The error of the policy or of accepting the notice, nil if there was none.
+}
+
+// handleMaintenanceNotice asks the policy about notice and accepts it if allowed. The outcome is recorded.
+func (a *authenticationState) handleMaintenanceNotice(
+	ctx context.Context,
+	login *five9types.LoginResponse,
+	notice five9types.MaintenanceNoticeInfo,
+) (accepted bool, err error) {
+	defer func() {
+		record := MaintenanceNoticeRecord{
+			Time:       time.Now(),
+			APIContext: a.apiContext(),
+			UserID:     login.UserID,
+			Notice:     notice,
+			Accepted:   accepted,
+			Err:        err,
+		}
+
+		a.client.logger.InfoContext(ctx, "five9 maintenance notice",
+			"api_context", a.apiContextPath,
+			"user_id", login.UserID,
+			"notice_id", notice.ID,
+			"annotation", notice.Annotation,
+			"accepted", accepted,
+			"error", err,
+		)
+
+		for _, recorder := range a.client.maintenanceNoticeRecorders {
+			recorder(record)
+		}
+	}()
+
+	if notice.Accepted {
+		return true, nil
+	}
+
+	accept, err := a.client.maintenanceNoticePolicy(ctx, notice)
+	if err != nil || !accept {
+		return false, err
+	}
+
+	if err := a.endpointAcceptMaintenanceNotice(ctx, notice.ID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
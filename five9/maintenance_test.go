@@ -0,0 +1,191 @@
+package five9_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9test"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+func Test_MaintenanceNoticePolicy_AcceptedByDefault(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	server.SetMaintenanceNotices(
+		five9types.MaintenanceNoticeInfo{ID: "8213", Annotation: "Service Update 9"},
+		five9types.MaintenanceNoticeInfo{ID: "8214", Annotation: "Service Update 10"},
+	)
+
+	mutex := &sync.Mutex{}
+	records := []five9.MaintenanceNoticeRecord{}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(
+			server.ConfigFuncs(),
+			five9.AddMaintenanceNoticeRecorder(func(record five9.MaintenanceNoticeRecord) {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				records = append(records, record)
+			}),
+		)...,
+	)
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, notice := range server.MaintenanceNotices() {
+		if !notice.Accepted {
+			t.Fatalf("expected notice %s to be accepted", notice.ID)
+		}
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	for _, record := range records {
+		if !record.Accepted || record.Err != nil || record.APIContext != five9.APIContextSupervisor || record.UserID == "" {
+			t.Fatalf("unexpected record %+v", record)
+		}
+	}
+}
+
+func Test_MaintenanceNoticePolicy_Callback(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	server.SetMaintenanceNotices(
+		five9types.MaintenanceNoticeInfo{ID: "8213", Annotation: "Service Update 9"},
+		five9types.MaintenanceNoticeInfo{ID: "8214", Annotation: "Terms of Service"},
+	)
+
+	records := []five9.MaintenanceNoticeRecord{}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(
+			server.ConfigFuncs(),
+			five9.SetMaintenanceNoticePolicy(
+				func(ctx context.Context, notice five9types.MaintenanceNoticeInfo) (bool, error) {
+					return notice.Annotation != "Terms of Service", nil
+				},
+			),
+			five9.AddMaintenanceNoticeRecorder(func(record five9.MaintenanceNoticeRecord) {
+				records = append(records, record)
+			}),
+		)...,
+	)
+
+	_, err := s.Supervisor().GetAllDomainUsers(ctx)
+	if !errors.Is(err, five9.ErrMaintenanceNoticesNotAccepted) {
+		t.Fatalf("expected ErrMaintenanceNoticesNotAccepted, got %v", err)
+	}
+
+	target := &five9.MaintenanceNoticesError{}
+	if !errors.As(err, &target) {
+		t.Fatalf("expected a MaintenanceNoticesError, got %T", err)
+	}
+
+	if len(target.Notices) != 1 || target.Notices[0].ID != "8214" {
+		t.Fatalf("expected notice 8214 to be rejected, got %+v", target.Notices)
+	}
+
+	if len(records) != 2 || !records[0].Accepted || records[1].Accepted {
+		t.Fatalf("expected notice 8213 to be recorded as accepted and 8214 as rejected, got %+v", records)
+	}
+
+	if notices := server.MaintenanceNotices(); !notices[0].Accepted || notices[1].Accepted {
+		t.Fatalf("expected only notice 8213 to be accepted, got %+v", notices)
+	}
+}
+
+func Test_MaintenanceNoticePolicy_CallbackError(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	server.SetMaintenanceNotices(five9types.MaintenanceNoticeInfo{ID: "8213", Annotation: "Service Update 9"})
+
+	policyErr := errors.New("approval service unavailable")
+	records := []five9.MaintenanceNoticeRecord{}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(
+			server.ConfigFuncs(),
+			five9.SetMaintenanceNoticePolicy(
+				func(ctx context.Context, notice five9types.MaintenanceNoticeInfo) (bool, error) {
+					return false, policyErr
+				},
+			),
+			five9.AddMaintenanceNoticeRecorder(func(record five9.MaintenanceNoticeRecord) {
+				records = append(records, record)
+			}),
+		)...,
+	)
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); !errors.Is(err, policyErr) {
+		t.Fatalf("expected the policy error, got %v", err)
+	}
+
+	if len(records) != 1 || records[0].Accepted || !errors.Is(records[0].Err, policyErr) {
+		t.Fatalf("expected the policy error to be recorded, got %+v", records)
+	}
+}
+
+func Test_MaintenanceNoticePolicy_CallsOtherService(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	server.SetMaintenanceNotices(five9types.MaintenanceNoticeInfo{ID: "8213", Annotation: "Service Update 9"})
+
+	// The approver is another domain, so logging it in doesn't end the session being set up.
+	approverServer := five9test.NewServer()
+	defer approverServer.Close()
+
+	approverServer.SetUsers(five9types.AgentInfo{ID: five9test.DefaultUserID, UserName: "approver@example.com"})
+
+	approver := five9.NewService(five9types.PasswordCredentials{}, approverServer.ConfigFuncs()...)
+
+	// The policy calls the approver with the context of the login it is part of.
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(
+			server.ConfigFuncs(),
+			five9.SetMaintenanceNoticePolicy(
+				func(ctx context.Context, notice five9types.MaintenanceNoticeInfo) (bool, error) {
+					if _, err := approver.Supervisor().GetOwnUserInfo(ctx); err != nil {
+						return false, err
+					}
+
+					return true, nil
+				},
+			),
+		)...,
+	)
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if notices := server.MaintenanceNotices(); !notices[0].Accepted {
+		t.Fatalf("expected notice 8213 to be accepted, got %+v", notices)
+	}
+}
@@ -0,0 +1,106 @@
+package five9
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestHandler sends a request to Five9. The innermost handler is the http.Client of the service.
+type RequestHandler func(request *http.Request) (*http.Response, error)
+
+// RequestMiddleware wraps every request sent to Five9, including the login, and each retry of a request.
+// It can inspect or replace the request and the response, for example to record metrics or redact a body.
+// Use RequestAttemptFromContext with the request context to find out which attempt is being sent.
+type RequestMiddleware func(next RequestHandler) RequestHandler
+
+// RequestAttempt describes a single attempt of a REST call.
+type RequestAttempt struct {
+	Method        string
+	URL           string
+	Attempt       int           // Starts at 1 and increases with every retry.
+	LoggedInAgain bool          // The request is being replayed after a 435 made the service log in again.
+	StatusCode    int           // Zero if no response was received.
+	Duration      time.Duration // Time taken to receive the response and, for REST calls, read the body.
+	Err           error         // The error returned by the attempt, nil if it succeeded.
+}
+
+type requestAttemptContextKey struct{}
+
+// RequestAttemptFromContext returns the attempt a request belongs to. Only Attempt and LoggedInAgain are set.
+// The second return value is false for requests that are not retried, such as the login.
+func RequestAttemptFromContext(ctx context.Context) (RequestAttempt, bool) {
+	attempt, ok := ctx.Value(requestAttemptContextKey{}).(RequestAttempt)
+
+	return attempt, ok
+}
+
+func withRequestAttempt(ctx context.Context, attempt RequestAttempt) context.Context {
+	return context.WithValue(ctx, requestAttemptContextKey{}, attempt)
+}
+
+// send waits for the rate limiter, then passes request through the middleware, the first added being the
+// outermost, to the http.Client. The request counts as in flight until the response body is closed.
+func (c *client) send(request *http.Request) (*http.Response, error) {
+	handler := RequestHandler(c.httpClient.Do)
+
+	for i := len(c.requestMiddleware) - 1; i >= 0; i-- {
+		handler = c.requestMiddleware[i](handler)
+	}
+
+	budget, err := c.rateLimiter.acquire(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := handler(request)
+	if err != nil {
+		budget.release()
+
+		return nil, err
+	}
+
+	if budget == nil {
+		return response, nil
+	}
+
+	if response.StatusCode == http.StatusTooManyRequests {
+		budget.throttle(parseRetryAfter(response.Header.Get("Retry-After")))
+	}
+
+	response.Body = releaseOnClose{
+		ReadCloser: response.Body,
+		once:       &sync.Once{},
+		budget:     budget,
+	}
+
+	return response, nil
+}
+
+// observe logs a finished attempt and reports it to the request observers.
+func (c *client) observe(request *http.Request, statusCode int, start time.Time, err error) {
+	attempt, ok := RequestAttemptFromContext(request.Context())
+	if !ok {
+		attempt.Attempt = 1
+	}
+
+	attempt.Method = request.Method
+	attempt.URL = request.URL.String()
+	attempt.StatusCode = statusCode
+	attempt.Duration = time.Since(start)
+	attempt.Err = err
+
+	c.logger.DebugContext(request.Context(), "five9 request",
+		"method", attempt.Method,
+		"path", request.URL.Path,
+		"attempt", attempt.Attempt,
+		"status", attempt.StatusCode,
+		"duration", attempt.Duration,
+		"error", attempt.Err,
+	)
+
+	for _, observer := range c.requestObservers {
+		observer(attempt)
+	}
+}
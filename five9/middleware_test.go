@@ -0,0 +1,152 @@
+package five9_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+func Test_RequestMiddleware_Order(t *testing.T) {
+	ctx := context.Background()
+
+	calls := []string{}
+
+	recordMiddleware := func(name string) five9.RequestMiddleware {
+		return func(next five9.RequestHandler) five9.RequestHandler {
+			return func(r *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" request")
+
+				response, err := next(r)
+
+				calls = append(calls, name+" response")
+
+				return response, err
+			}
+		}
+	}
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.AddRequestMiddleware(recordMiddleware("outer"), recordMiddleware("inner")),
+	)
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// One request for each of the 5 login calls, and one for the users.
+	if len(calls) != 6*4 {
+		t.Fatalf("expected 24 middleware calls, got %d", len(calls))
+	}
+
+	expected := []string{"outer request", "inner request", "inner response", "outer response"}
+	if strings.Join(calls[:4], ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected %v, got %v", expected, calls[:4])
+	}
+}
+
+func Test_RequestObserver_Attempts(t *testing.T) {
+	ctx := context.Background()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       http.NoBody,
+					StatusCode: http.StatusServiceUnavailable,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_435.json"),
+					StatusCode: 435,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // https://app.five9.com/supsvcs/rs/svc/auth/login
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisorLogin_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/auth/metadata
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/auth_metadata_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/login_state
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/loginState_working_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+		),
+	}
+
+	attempts := []five9.RequestAttempt{}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRetryPolicy(five9.RetryPolicy{
+			InitialBackoff: time.Millisecond,
+		}),
+		five9.AddRequestObserver(func(attempt five9.RequestAttempt) {
+			if strings.HasSuffix(attempt.URL, "/users") {
+				attempts = append(attempts, attempt)
+			}
+		}),
+	)
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []five9.RequestAttempt{
+		{Method: http.MethodGet, Attempt: 1, StatusCode: http.StatusServiceUnavailable},
+		{Method: http.MethodGet, Attempt: 2, StatusCode: 435},
+		{Method: http.MethodGet, Attempt: 2, StatusCode: http.StatusOK, LoggedInAgain: true},
+	}
+
+	if len(attempts) != len(expected) {
+		t.Fatalf("expected %d attempts, got %+v", len(expected), attempts)
+	}
+
+	for i, attempt := range attempts {
+		if attempt.Method != expected[i].Method ||
+			attempt.Attempt != expected[i].Attempt ||
+			attempt.StatusCode != expected[i].StatusCode ||
+			attempt.LoggedInAgain != expected[i].LoggedInAgain {
+			t.Errorf("attempt %d: expected %+v, got %+v", i, expected[i], attempt)
+		}
+
+		if (attempt.Err == nil) != (attempt.StatusCode == http.StatusOK) {
+			t.Errorf("attempt %d: unexpected error %v", i, attempt.Err)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package five9
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIContext is the first segment of the path of a Five9 REST call, each of which Five9 throttles separately.
+type APIContext string
+
+const (
+	APIContextSupervisor APIContext = "supsvcs"
+	APIContextAgent      APIContext = "appsvcs"
+	APIContextStatistics APIContext = "strsvcs"
+)
+
+// RateLimit is the request budget of a single API context.
+type RateLimit struct {
+	RequestsPerSecond float64 // Average rate requests are sent at. Zero disables the rate limit.
+	Burst             int     // Requests that can be sent at once before the rate applies. Defaults to 1.
+	MaxInFlight       int     // Requests that can wait for a response at the same time. Zero disables the cap.
+
+	// Backoff pauses every request of the API context after a 429 Too Many Requests without a Retry-After.
+	// Defaults to 1 second.
+	Backoff time.Duration
+}
+
+// RateLimiter holds a budget for each API context. Pass the same RateLimiter to SetRateLimiter of several
+// services to share the budgets between them. API contexts without a RateLimit are not limited.
+type RateLimiter struct {
+	budgets map[APIContext]*requestBudget
+}
+
+func NewRateLimiter(limits map[APIContext]RateLimit) *RateLimiter {
+	limiter := &RateLimiter{
+		budgets: map[APIContext]*requestBudget{},
+	}
+
+	for apiContext, limit := range limits {
+		limiter.budgets[apiContext] = newRequestBudget(limit)
+	}
+
+	return limiter
+}
+
+// acquire waits until request can be sent, or the context of the request is done. The returned budget is nil
+// if the API context of request is not limited.
+func (l *RateLimiter) acquire(request *http.Request) (*requestBudget, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	apiContext, _, _ := strings.Cut(strings.TrimPrefix(request.URL.Path, "/"), "/")
+
+	budget, ok := l.budgets[APIContext(apiContext)]
+	if !ok {
+		return nil, nil
+	}
+
+	if err := budget.wait(request.Context()); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+// requestBudget is a token bucket, with a semaphore for the requests in flight.
+type requestBudget struct {
+	limit    RateLimit
+	inFlight chan struct{}
+
+	mutex       *sync.Mutex
+	tokens      float64
+	lastRefill  time.Time
+	pausedUntil time.Time
+}
+
+func newRequestBudget(limit RateLimit) *requestBudget {
+	if limit.Burst <= 0 {
+		limit.Burst = 1
+	}
+
+	if limit.Backoff <= 0 {
+		limit.Backoff = time.Second
+	}
+
+	budget := &requestBudget{
+		limit:      limit,
+		mutex:      &sync.Mutex{},
+		tokens:     float64(limit.Burst),
+		lastRefill: time.Now(),
+	}
+
+	if limit.MaxInFlight > 0 {
+		budget.inFlight = make(chan struct{}, limit.MaxInFlight)
+	}
+
+	return budget
+}
+
+// wait takes a slot for a request in flight and then a token. The slot is taken first, so that requests waiting
+// for a slot don't use up tokens. It must be given back with release.
+func (b *requestBudget) wait(ctx context.Context) error {
+	if b.inFlight != nil {
+		select {
+		case b.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for {
+		delay := b.reserve()
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			b.release()
+
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token if one is available, or returns how long to wait before trying again.
+func (b *requestBudget) reserve() time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := time.Now()
+
+	if now.Before(b.pausedUntil) {
+		return b.pausedUntil.Sub(now)
+	}
+
+	if b.limit.RequestsPerSecond <= 0 {
+		return 0
+	}
+
+	b.tokens += now.Sub(b.lastRefill).Seconds() * b.limit.RequestsPerSecond
+	if b.tokens > float64(b.limit.Burst) {
+		b.tokens = float64(b.limit.Burst)
+	}
+
+	b.lastRefill = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+
+		return 0
+	}
+
+	return time.Duration((1 - b.tokens) / b.limit.RequestsPerSecond * float64(time.Second))
+}
+
+// release gives back the slot taken by wait.
+func (b *requestBudget) release() {
+	if b == nil || b.inFlight == nil {
+		return
+	}
+
+	<-b.inFlight
+}
+
+// throttle pauses the API context after Five9 replied with 429 Too Many Requests.
+func (b *requestBudget) throttle(retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		retryAfter = b.limit.Backoff
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if pausedUntil := time.Now().Add(retryAfter); pausedUntil.After(b.pausedUntil) {
+		b.pausedUntil = pausedUntil
+	}
+}
+
+// releaseOnClose gives back the slot of a request once its response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once   *sync.Once
+	budget *requestBudget
+}
+
+func (r releaseOnClose) Close() error {
+	r.once.Do(r.budget.release)
+
+	return r.ReadCloser.Close()
+}
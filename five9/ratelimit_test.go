@@ -0,0 +1,258 @@
+package five9_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9test"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+func Test_RateLimiter_RequestsPerSecond(t *testing.T) {
+	ctx := context.Background()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRateLimiter(five9.NewRateLimiter(map[five9.APIContext]five9.RateLimit{
+			five9.APIContextSupervisor: {RequestsPerSecond: 20},
+		})),
+	)
+
+	start := time.Now()
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first of the 6 requests is sent straight away, the others 50ms apart.
+	if elapsed := time.Since(start); elapsed < time.Millisecond*250 {
+		t.Fatalf("expected the requests to be rate limited, took %s", elapsed)
+	}
+}
+
+func Test_RateLimiter_MaxInFlightSharedBetweenServices(t *testing.T) {
+	ctx := context.Background()
+
+	inFlight := atomic.Int32{}
+	maxInFlight := atomic.Int32{}
+
+	recordInFlight := func(next five9.RequestHandler) five9.RequestHandler {
+		return func(r *http.Request) (*http.Response, error) {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+
+			for {
+				previous := maxInFlight.Load()
+				if current <= previous || maxInFlight.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond * 10)
+
+			return next(r)
+		}
+	}
+
+	limiter := five9.NewRateLimiter(map[five9.APIContext]five9.RateLimit{
+		five9.APIContextSupervisor: {MaxInFlight: 2},
+	})
+
+	services := []*five9.Service{}
+	for i := 0; i < 2; i++ {
+		server := five9test.NewServer()
+		defer server.Close()
+
+		s := five9.NewService(
+			five9types.PasswordCredentials{},
+			append(
+				server.ConfigFuncs(),
+				five9.SetRateLimiter(limiter),
+				five9.AddRequestMiddleware(recordInFlight),
+			)...,
+		)
+
+		// Log in before sending requests concurrently.
+		if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		services = append(services, s)
+	}
+
+	wg := &sync.WaitGroup{}
+
+	for i := 0; i < 10; i++ {
+		for _, s := range services {
+			wg.Add(1)
+
+			go func(s *five9.Service) {
+				defer wg.Done()
+
+				if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+					t.Error(err)
+				}
+			}(s)
+		}
+	}
+
+	wg.Wait()
+
+	if maxInFlight.Load() != 2 {
+		t.Fatalf("expected at most 2 requests in flight across both services, got %d", maxInFlight.Load())
+	}
+}
+
+func Test_RateLimiter_ContextCancelledWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: generateLoginRequestFuncs(t),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRateLimiter(five9.NewRateLimiter(map[five9.APIContext]five9.RateLimit{
+			five9.APIContextSupervisor: {RequestsPerSecond: 0.001},
+		})),
+	)
+
+	_, err := s.Supervisor().GetAllDomainUsers(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// Only the login was sent before the budget ran out.
+	if len(mockRoundTripper.Func) != 4 {
+		t.Fatalf("expected 1 request to be sent, %d api requests remaining in queue", len(mockRoundTripper.Func))
+	}
+}
+
+func Test_RateLimiter_BacksOffOnTooManyRequests(t *testing.T) {
+	ctx := context.Background()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       http.NoBody,
+					StatusCode: http.StatusTooManyRequests,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRetryPolicy(five9.RetryPolicy{
+			InitialBackoff: time.Millisecond,
+		}),
+		five9.SetRateLimiter(five9.NewRateLimiter(map[five9.APIContext]five9.RateLimit{
+			five9.APIContextSupervisor: {Backoff: time.Millisecond * 200},
+		})),
+	)
+
+	start := time.Now()
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Millisecond*200 {
+		t.Fatalf("expected the limiter to back off after a 429, took %s", elapsed)
+	}
+}
+
+func Test_RateLimiter_RequestWaitingForSlotKeepsToken(t *testing.T) {
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				close(started)
+				<-unblock
+
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+		),
+	}
+
+	// The burst covers the 5 login requests and the 2 requests that are sent, and no token is refilled.
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRateLimiter(five9.NewRateLimiter(map[five9.APIContext]five9.RateLimit{
+			five9.APIContextSupervisor: {RequestsPerSecond: 0.001, Burst: 7, MaxInFlight: 1},
+		})),
+	)
+
+	blocked := make(chan error)
+	go func() {
+		_, err := s.Supervisor().GetAllDomainUsers(ctx)
+		blocked <- err
+	}()
+
+	<-started
+
+	waitingCtx, cancelWaiting := context.WithTimeout(ctx, time.Millisecond*50)
+	defer cancelWaiting()
+
+	if _, err := s.Supervisor().GetAllDomainUsers(waitingCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded while waiting for a slot, got %v", err)
+	}
+
+	close(unblock)
+
+	if err := <-blocked; err != nil {
+		t.Fatal(err)
+	}
+
+	nextCtx, cancelNext := context.WithTimeout(ctx, time.Second)
+	defer cancelNext()
+
+	if _, err := s.Supervisor().GetAllDomainUsers(nextCtx); err != nil {
+		t.Fatalf("expected the request that gave up on a slot to leave its token, got %v", err)
+	}
+}
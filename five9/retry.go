@@ -0,0 +1,152 @@
+package five9
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed REST calls are retried. Zero values are replaced with sensible defaults.
+// A Retry-After sent by Five9 is always waited for in full. If it is longer than MaxBackoff, the call is not retried
+// and the error is returned instead.
+type RetryPolicy struct {
+	MaxAttempts    int           // Attempts per call, including the first. Defaults to 3.
+	InitialBackoff time.Duration // Delay before the first retry. Defaults to 1 second.
+	MaxBackoff     time.Duration // Upper bound for the delay between attempts. Defaults to 30 seconds.
+	Multiplier     float64       // Growth factor applied to the delay after each failed attempt. Defaults to 2.
+	Jitter         float64       // Fraction (0-1) of the delay that is randomised. Defaults to 0.2.
+	DisableJitter  bool          // Waits exactly the computed delay, ignoring Jitter.
+
+	// Retryable decides whether a failed attempt should be retried. Defaults to RetryableError.
+	Retryable func(err error) bool
+}
+
+// RetryableError is the default RetryPolicy.Retryable. It retries transport errors, 429 Too Many Requests, the 5xx
+// statuses that are usually transient, and 401 Unauthorized, which Five9 returns for a short time after logging in
+// while the session propagates across their data centers.
+func RetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	five9Error := &Error{}
+	if errors.As(err, &five9Error) {
+		switch five9Error.StatusCode {
+		case http.StatusUnauthorized,
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		}
+
+		return false
+	}
+
+	urlError := &url.Error{}
+
+	return errors.As(err, &urlError)
+}
+
+func (policy RetryPolicy) withDefaults() RetryPolicy {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 3
+	}
+
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = time.Second
+	}
+
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = time.Second * 30
+	}
+
+	if policy.MaxBackoff < policy.InitialBackoff {
+		policy.MaxBackoff = policy.InitialBackoff
+	}
+
+	if policy.Multiplier < 1 {
+		policy.Multiplier = 2
+	}
+
+	if policy.DisableJitter {
+		policy.Jitter = 0
+	} else if policy.Jitter <= 0 || policy.Jitter > 1 {
+		policy.Jitter = 0.2
+	}
+
+	if policy.Retryable == nil {
+		policy.Retryable = RetryableError
+	}
+
+	return policy
+}
+
+// backoff returns the delay after the given failed attempt (starting at 1). A Retry-After sent by Five9 is used
+// instead, as long as it is longer than the computed delay. It returns false if the Retry-After is longer than
+// MaxBackoff, as retrying any sooner would ignore it.
+func (policy RetryPolicy) backoff(attempt int, err error) (time.Duration, bool) {
+	delay := float64(policy.InitialBackoff)
+	for i := 1; i < attempt && delay < float64(policy.MaxBackoff); i++ {
+		delay *= policy.Multiplier
+	}
+
+	if delay > float64(policy.MaxBackoff) {
+		delay = float64(policy.MaxBackoff)
+	}
+
+	spread := delay * policy.Jitter
+	backoff := time.Duration(delay - spread + rand.Float64()*2*spread)
+
+	if backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
+	}
+
+	five9Error := &Error{}
+	if errors.As(err, &five9Error) && five9Error.RetryAfter > backoff {
+		if five9Error.RetryAfter > policy.MaxBackoff {
+			return 0, false
+		}
+
+		backoff = five9Error.RetryAfter
+	}
+
+	return backoff, true
+}
+
+// wait sleeps for backoff, returning early with the context error if the context is done.
+func (policy RetryPolicy) wait(ctx context.Context, backoff time.Duration) error {
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// parseRetryAfter reads a Retry-After header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,420 @@
+package five9_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+func Test_Retry_TransientStatus_Success(t *testing.T) {
+	ctx := context.Background()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       io.NopCloser(strings.NewReader("<html>Service Unavailable</html>")),
+					StatusCode: http.StatusServiceUnavailable,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       http.NoBody,
+					StatusCode: http.StatusTooManyRequests,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRetryPolicy(five9.RetryPolicy{
+			InitialBackoff: time.Millisecond,
+		}),
+	)
+
+	users, err := s.Supervisor().GetAllDomainUsers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) == 0 {
+		t.Fatal("expected users to be returned")
+	}
+
+	if len(mockRoundTripper.Func) != 0 {
+		t.Fatalf("did not make all expected API calls - %d api requests remaining in queue", len(mockRoundTripper.Func))
+	}
+}
+
+func Test_Retry_GivesUpAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+
+	serviceUnavailable := func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			Body:       http.NoBody,
+			StatusCode: http.StatusServiceUnavailable,
+		}, nil
+	}
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(generateLoginRequestFuncs(t), serviceUnavailable, serviceUnavailable),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRetryPolicy(five9.RetryPolicy{
+			MaxAttempts:    2,
+			InitialBackoff: time.Millisecond,
+		}),
+	)
+
+	_, err := s.Supervisor().GetAllDomainUsers(ctx)
+
+	five9Error := &five9.Error{}
+	if !errors.As(err, &five9Error) || five9Error.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected a 503 error, got %v", err)
+	}
+
+	if len(mockRoundTripper.Func) != 0 {
+		t.Fatalf("expected 2 attempts - %d api requests remaining in queue", len(mockRoundTripper.Func))
+	}
+}
+
+func Test_Retry_NotRetryableStatus(t *testing.T) {
+	ctx := context.Background()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       http.NoBody,
+					StatusCode: http.StatusForbidden,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) {
+				t.Error("request with status 403 should not be retried")
+
+				return nil, errors.New("unexpected request")
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRetryPolicy(five9.RetryPolicy{
+			InitialBackoff: time.Millisecond,
+		}),
+	)
+
+	_, err := s.Supervisor().GetAllDomainUsers(ctx)
+
+	five9Error := &five9.Error{}
+	if !errors.As(err, &five9Error) || five9Error.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected a 403 error, got %v", err)
+	}
+}
+
+func Test_Retry_HonorsRetryAfter(t *testing.T) {
+	ctx := context.Background()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Header:     http.Header{"Retry-After": []string{"1"}},
+					Body:       http.NoBody,
+					StatusCode: http.StatusTooManyRequests,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRetryPolicy(five9.RetryPolicy{
+			InitialBackoff: time.Millisecond,
+		}),
+	)
+
+	start := time.Now()
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected to wait for Retry-After, only waited %s", elapsed)
+	}
+}
+
+func Test_Retry_ContextCancelledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       http.NoBody,
+					StatusCode: http.StatusServiceUnavailable,
+				}, nil
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRetryPolicy(five9.RetryPolicy{
+			InitialBackoff: time.Minute,
+		}),
+	)
+
+	start := time.Now()
+
+	_, err := s.Supervisor().GetAllDomainUsers(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second*5 {
+		t.Fatalf("backoff was not interrupted by the context, took %s", elapsed)
+	}
+}
+
+func Test_Retry_ResendsBody_SetStatisticsFilterSettings(t *testing.T) {
+	ctx := context.Background()
+
+	payload := `{"range":"CURRENT_DAY"}`
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/stats_filter_settings
+				assertRequestBody(t, r, payload)
+
+				return &http.Response{
+					Body:       http.NoBody,
+					StatusCode: http.StatusUnauthorized,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/stats_filter_settings
+				assertRequestBody(t, r, payload)
+
+				return &http.Response{
+					Body:       io.NopCloser(strings.NewReader("[]")),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRetryPolicy(five9.RetryPolicy{
+			InitialBackoff: time.Millisecond,
+		}),
+	)
+
+	if _, err := s.Supervisor().SetStatisticsFilterSettings(ctx, map[string]string{"range": "CURRENT_DAY"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mockRoundTripper.Func) != 0 {
+		t.Fatalf("did not make all expected API calls - %d api requests remaining in queue", len(mockRoundTripper.Func))
+	}
+}
+
+func Test_Retry_ResendsBody_StartSession(t *testing.T) {
+	ctx := context.Background()
+
+	payload := `{"stationId":"","stationType":"EMPTY"}`
+
+	loginRequestFuncs := generateLoginRequestFuncs(t)
+
+	mockRoundTripper := MockRoundTripper{
+		Func: []func(r *http.Request) (*http.Response, error){
+			loginRequestFuncs[0], // supsvcs/rs/svc/auth/login
+			loginRequestFuncs[1], // supsvcs/rs/svc/auth/metadata
+			loginRequestFuncs[2], // supsvcs/rs/svc/supervisors/:userID/login_state
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/session_start?force=true
+				assertRequestBody(t, r, payload)
+
+				return &http.Response{
+					Body:       http.NoBody,
+					StatusCode: http.StatusUnauthorized,
+				}, nil
+			},
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/session_start?force=true
+				assertRequestBody(t, r, payload)
+
+				return &http.Response{
+					Body:       http.NoBody,
+					StatusCode: http.StatusNoContent,
+				}, nil
+			},
+			loginRequestFuncs[4], // supsvcs/rs/svc/supervisors/:userID/login_state
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+		},
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRetryPolicy(five9.RetryPolicy{
+			InitialBackoff: time.Millisecond,
+		}),
+	)
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mockRoundTripper.Func) != 0 {
+		t.Fatalf("did not make all expected API calls - %d api requests remaining in queue", len(mockRoundTripper.Func))
+	}
+}
+
+func assertRequestBody(t *testing.T, r *http.Request, expected string) {
+	t.Helper()
+
+	if r.Body == nil {
+		t.Errorf("expected body %s, got no body", expected)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Error(err)
+
+		return
+	}
+
+	if string(body) != expected {
+		t.Errorf("expected body %s, got %s", expected, body)
+	}
+}
+
+func Test_Retry_RetryAfterLongerThanMaxBackoff(t *testing.T) {
+	ctx := context.Background()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Header:     http.Header{"Retry-After": []string{"120"}},
+					Body:       http.NoBody,
+					StatusCode: http.StatusTooManyRequests,
+				}, nil
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRetryPolicy(five9.RetryPolicy{
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Second,
+		}),
+	)
+
+	start := time.Now()
+
+	_, err := s.Supervisor().GetAllDomainUsers(ctx)
+
+	five9Error := &five9.Error{}
+	if !errors.As(err, &five9Error) || five9Error.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected the 429 error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Fatalf("expected to give up without waiting, waited %s", elapsed)
+	}
+}
+
+func Test_Retry_DisableJitter(t *testing.T) {
+	ctx := context.Background()
+
+	backoff := time.Millisecond * 20
+	attempts := []time.Time{}
+
+	serviceUnavailable := func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+		attempts = append(attempts, time.Now())
+
+		return &http.Response{
+			Body:       http.NoBody,
+			StatusCode: http.StatusServiceUnavailable,
+		}, nil
+	}
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			serviceUnavailable,
+			serviceUnavailable,
+			serviceUnavailable,
+			serviceUnavailable,
+			serviceUnavailable,
+			serviceUnavailable,
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetRetryPolicy(five9.RetryPolicy{
+			MaxAttempts:    6,
+			InitialBackoff: backoff,
+			Multiplier:     1,
+			DisableJitter:  true,
+		}),
+	)
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err == nil {
+		t.Fatal("expected the last 503 to be returned")
+	}
+
+	// With jitter, some of the delays would be shorter than the backoff.
+	for i := 1; i < len(attempts); i++ {
+		if delay := attempts[i].Sub(attempts[i-1]); delay < backoff {
+			t.Fatalf("expected every retry to wait %s, waited %s", backoff, delay)
+		}
+	}
+}
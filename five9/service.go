@@ -23,9 +23,16 @@ func NewService(
 	defaultCacheAllowedAge := time.Hour
 
 	c := &client{
-		credentials:          creds,
-		httpClient:           httpClient,
-		requestPreProcessors: []func(r *http.Request) error{},
+		credentialsProvider:     StaticCredentials(creds),
+		loginPolicy:             five9types.PolicyForceIn,
+		loginBaseURL:            LoginBaseURLUS,
+		apiServerStrategy:       ActiveDataCenters,
+		retryPolicy:             RetryPolicy{}.withDefaults(),
+		logger:                  newDiscardLogger(),
+		telemetry:               newTelemetry(nil, nil),
+		maintenanceNoticePolicy: AcceptMaintenanceNotices,
+		httpClient:              httpClient,
+		requestPreProcessors:    []func(r *http.Request) error{},
 	}
 
 	s := &Service{
@@ -35,6 +42,7 @@ func NewService(
 				client:         c,
 				apiContextPath: agentAPIContextPath,
 				loginMutex:     &sync.Mutex{},
+				apiHosts:       &apiHostList{mutex: &sync.Mutex{}},
 			},
 		},
 		// ** //
@@ -43,6 +51,7 @@ func NewService(
 				client:         c,
 				apiContextPath: supervisorAPIContextPath,
 				loginMutex:     &sync.Mutex{},
+				apiHosts:       &apiHostList{mutex: &sync.Mutex{}},
 			},
 			domainMetadataCache: &domainMetadataCache{
 				agentInfoState: utils.NewMemoryCacheInstance[
@@ -57,8 +66,17 @@ func NewService(
 					five9types.QueueID,
 					five9types.QueueInfo,
 				](&defaultCacheAllowedAge),
+				campaignInfoState: utils.NewMemoryCacheInstance[
+					five9types.CampaignID,
+					five9types.CampaignInfo,
+				](&defaultCacheAllowedAge),
 			},
 			webSocketHandler: &liveWebsocketHandler{},
+			webSocketMutex:   &sync.Mutex{},
+			webSocketEvents:  newWebSocketEventBroker(),
+			webSocketSequence: &webSocketSequenceTracker{
+				mutex: &sync.Mutex{},
+			},
 			webSocketCache: &supervisorWebSocketCache{
 				agentState: utils.NewMemoryCacheInstance[
 					five9types.UserID,
@@ -72,6 +90,30 @@ func NewService(
 					five9types.QueueID,
 					five9types.ACDState,
 				](&defaultCacheAllowedAge),
+				campaignState: utils.NewMemoryCacheInstance[
+					five9types.CampaignID,
+					five9types.WebSocketStatisticsCampaignStateData,
+				](&defaultCacheAllowedAge),
+				inboundCampaignStatistics: utils.NewMemoryCacheInstance[
+					five9types.CampaignID,
+					five9types.WebSocketStatisticsInboundCampaignStatisticsData,
+				](&defaultCacheAllowedAge),
+				outboundCampaignStatistics: utils.NewMemoryCacheInstance[
+					five9types.CampaignID,
+					five9types.WebSocketStatisticsOutboundCampaignStatisticsData,
+				](&defaultCacheAllowedAge),
+				outboundCampaignManager: utils.NewMemoryCacheInstance[
+					five9types.CampaignID,
+					five9types.WebSocketStatisticsOutboundCampaignManagerData,
+				](&defaultCacheAllowedAge),
+				userSessions: utils.NewMemoryCacheInstance[
+					five9types.SessionID,
+					five9types.WebSocketStatisticsUserSessionData,
+				](&defaultCacheAllowedAge),
+				stations: utils.NewMemoryCacheInstance[
+					five9types.StationID,
+					five9types.WebSocketStatisticsStationData,
+				](&defaultCacheAllowedAge),
 				timers: utils.NewMemoryCacheInstance[
 					five9types.EventID,
 					*time.Time,
@@ -84,6 +126,7 @@ func NewService(
 				client:         c,
 				apiContextPath: supervisorAPIContextPath,
 				loginMutex:     &sync.Mutex{},
+				apiHosts:       &apiHostList{mutex: &sync.Mutex{}},
 			},
 		},
 	}
@@ -120,4 +163,5 @@ type domainMetadataCache struct {
 	reasonCodeInfoState *utils.MemoryCacheInstance[five9types.ReasonCodeID, five9types.ReasonCodeInfo]
 	agentInfoState      *utils.MemoryCacheInstance[five9types.UserID, five9types.AgentInfo]
 	queueInfoState      *utils.MemoryCacheInstance[five9types.QueueID, five9types.QueueInfo]
+	campaignInfoState   *utils.MemoryCacheInstance[five9types.CampaignID, five9types.CampaignInfo]
 }
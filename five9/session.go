@@ -0,0 +1,180 @@
+package five9
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// SessionStore persists the login of a service, so the next process can resume the session instead of logging in.
+// A stored session is validated before it is used, and deleted if Five9 no longer accepts it.
+type SessionStore interface {
+	// Load returns false if no session is stored for apiContext.
+	Load(ctx context.Context, apiContext APIContext) (Token, bool, error)
+	Save(ctx context.Context, apiContext APIContext, token Token) error
+	Delete(ctx context.Context, apiContext APIContext) error
+}
+
+// FileSessionStore stores each session as a JSON file in a directory. The files hold the session cookies,
+// so they are only readable by the current user.
+type FileSessionStore struct {
+	directory string
+}
+
+func NewFileSessionStore(directory string) *FileSessionStore {
+	return &FileSessionStore{
+		directory: directory,
+	}
+}
+
+func (store *FileSessionStore) Load(_ context.Context, apiContext APIContext) (Token, bool, error) {
+	tokenBytes, err := os.ReadFile(store.path(apiContext))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Token{}, false, nil
+	}
+
+	if err != nil {
+		return Token{}, false, err
+	}
+
+	token := Token{}
+	if err := json.Unmarshal(tokenBytes, &token); err != nil {
+		return Token{}, false, err
+	}
+
+	return token, true, nil
+}
+
+func (store *FileSessionStore) Save(_ context.Context, apiContext APIContext, token Token) error {
+	tokenBytes, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(store.directory, 0o700); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so a process that is loading the session never reads half a file.
+	file, err := os.CreateTemp(store.directory, ".five9-session-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(tokenBytes); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), store.path(apiContext))
+}
+
+func (store *FileSessionStore) Delete(_ context.Context, apiContext APIContext) error {
+	if err := os.Remove(store.path(apiContext)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (store *FileSessionStore) path(apiContext APIContext) string {
+	return filepath.Join(store.directory, "five9-session-"+string(apiContext)+".json")
+}
+
+// restoreSession returns the stored session if Five9 still accepts it. It is only tried for the first login, as
+// a later login means the session that was stored has been migrated or logged out.
+func (a *authenticationState) restoreSession(ctx context.Context) (Token, bool) {
+	if a.client.sessionStore == nil || a.sessionRestoreAttempted {
+		return Token{}, false
+	}
+
+	a.sessionRestoreAttempted = true
+
+	token, ok := a.loadSession(ctx)
+	if !ok {
+		return Token{}, false
+	}
+
+	if err := a.validateSession(ctx, token); err != nil {
+		a.client.logger.InfoContext(ctx, "five9 stored session is no longer valid, logging in",
+			"api_context", a.apiContextPath,
+			"error", err,
+		)
+
+		a.deleteSession(ctx)
+
+		return Token{}, false
+	}
+
+	a.client.logger.InfoContext(ctx, "five9 resumed stored session", "api_context", a.apiContextPath, "login", token.LoginResponse)
+
+	return token, true
+}
+
+// loadSession loads the stored session, if there is one, and sends the next requests with its token.
+func (a *authenticationState) loadSession(ctx context.Context) (Token, bool) {
+	if a.client.sessionStore == nil {
+		return Token{}, false
+	}
+
+	token, ok, err := a.client.sessionStore.Load(ctx, a.apiContext())
+	if err != nil {
+		a.client.logger.WarnContext(ctx, "five9 loading stored session failed", "api_context", a.apiContextPath, "error", err)
+
+		return Token{}, false
+	}
+
+	if !ok {
+		return Token{}, false
+	}
+
+	a.useToken(token)
+	a.apiHosts.reset(a.client.apiServerStrategy(token.LoginResponse))
+
+	return token, true
+}
+
+// validateSession checks token with a single request for the session metadata, without retrying or logging in.
+func (a *authenticationState) validateSession(ctx context.Context, token Token) error {
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		"/"+a.apiContextPath+"/auth/metadata",
+		http.NoBody,
+	)
+	if err != nil {
+		return err
+	}
+
+	return a.requestWithLogin(request, &token.LoginResponse, nil)
+}
+
+func (a *authenticationState) saveSession(ctx context.Context, token Token) {
+	if a.client.sessionStore == nil {
+		return
+	}
+
+	if err := a.client.sessionStore.Save(ctx, a.apiContext(), token); err != nil {
+		a.client.logger.WarnContext(ctx, "five9 storing session failed", "api_context", a.apiContextPath, "error", err)
+	}
+}
+
+func (a *authenticationState) deleteSession(ctx context.Context) {
+	if a.client.sessionStore == nil {
+		return
+	}
+
+	if err := a.client.sessionStore.Delete(ctx, a.apiContext()); err != nil {
+		a.client.logger.WarnContext(ctx, "five9 deleting stored session failed", "api_context", a.apiContextPath, "error", err)
+	}
+}
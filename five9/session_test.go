@@ -0,0 +1,115 @@
+package five9_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9test"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+func Test_SessionStore_ResumesStoredSession(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	directory := t.TempDir()
+
+	first := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(server.ConfigFuncs(), five9.SetSessionStore(five9.NewFileSessionStore(directory)))...,
+	)
+
+	if _, err := first.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(filepath.Join(directory, "five9-session-supsvcs.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected the session file to only be readable by the owner, got %s", info.Mode().Perm())
+	}
+
+	// A new process with the same store resumes the session.
+	second := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(server.ConfigFuncs(), five9.SetSessionStore(five9.NewFileSessionStore(directory)))...,
+	)
+
+	if _, err := second.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if logins := countRequests(server, "POST /supsvcs/rs/svc/auth/login"); logins != 1 {
+		t.Fatalf("expected the stored session to be resumed, got %d logins", logins)
+	}
+}
+
+func Test_SessionStore_InvalidSessionLogsIn(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	store := five9.NewFileSessionStore(t.TempDir())
+
+	first := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(server.ConfigFuncs(), five9.SetSessionStore(store))...,
+	)
+
+	if _, err := first.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Logging in elsewhere ends the stored session.
+	other := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+	if _, err := other.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, _, err := store.Load(ctx, five9.APIContextSupervisor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(server.ConfigFuncs(), five9.SetSessionStore(store))...,
+	)
+
+	if _, err := second.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if logins := countRequests(server, "POST /supsvcs/rs/svc/auth/login"); logins != 3 {
+		t.Fatalf("expected the invalid session to be replaced by a new login, got %d logins", logins)
+	}
+
+	replaced, ok, err := store.Load(ctx, five9.APIContextSupervisor)
+	if err != nil || !ok {
+		t.Fatalf("expected the new session to be stored, got %v", err)
+	}
+
+	if len(replaced.Cookies) == 0 || len(stored.Cookies) == 0 || replaced.Cookies[0].Value == stored.Cookies[0].Value {
+		t.Fatalf("expected the new session to replace the invalid one, got %+v", replaced.Cookies)
+	}
+}
+
+func countRequests(server *five9test.Server, request string) int {
+	count := 0
+	for _, sent := range server.Requests() {
+		if sent == request {
+			count++
+		}
+	}
+
+	return count
+}
@@ -2,25 +2,38 @@ package five9
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/equalsgibson/five9-go/five9/five9types"
 )
 
 type SupervisorService struct {
 	authState           *authenticationState
-	webSocketHandler    webSocketHandler
+	webSocketHandler    WebSocketHandler
 	webSocketCache      *supervisorWebSocketCache
+	webSocketEvents     *webSocketEventBroker
+	webSocketSequence   *webSocketSequenceTracker
 	domainMetadataCache *domainMetadataCache
+
+	webSocketMutex  *sync.Mutex
+	webSocketCancel context.CancelCauseFunc // Closes the current WebSocket connection, if there is one.
+	webSocketClosed bool                    // Set by Logout, so that no new WebSocket connection is made.
 }
 
 func (s *SupervisorService) GetOwnUserInfo(ctx context.Context) (five9types.AgentInfo, error) {
+	login, err := s.authState.getLogin(ctx)
+	if err != nil {
+		return five9types.AgentInfo{}, err
+	}
+
 	users, err := s.getDomainUserInfoMap(ctx)
 	if err != nil {
 		return five9types.AgentInfo{}, err
 	}
 
-	self, ok := users[s.authState.loginResponse.UserID]
+	self, ok := users[login.UserID]
 	if !ok {
 		return five9types.AgentInfo{}, ErrUnknownUserID
 	}
@@ -74,6 +87,12 @@ func (s *SupervisorService) getDomainUserInfoMap(ctx context.Context) (map[five9
 		return allUserInfo.Items, nil
 	}
 
+	return s.refreshDomainUserInfoMap(ctx, s.domainMetadataCache.agentInfoState.Generation())
+}
+
+// refreshDomainUserInfoMap fetches the domain users and caches them, unless the cache has been reset since generation
+// was read.
+func (s *SupervisorService) refreshDomainUserInfoMap(ctx context.Context, generation uint64) (map[five9types.UserID]five9types.AgentInfo, error) {
 	domainUserSlice, err := s.GetAllDomainUsers(ctx)
 	if err != nil {
 		return nil, err
@@ -85,7 +104,7 @@ func (s *SupervisorService) getDomainUserInfoMap(ctx context.Context) (map[five9
 		freshData[domainUser.ID] = domainUser
 	}
 
-	s.domainMetadataCache.agentInfoState.Replace(freshData)
+	s.domainMetadataCache.agentInfoState.ReplaceIfGeneration(generation, freshData)
 
 	return freshData, nil
 }
@@ -116,6 +135,10 @@ func (s *SupervisorService) getQueueInfoMap(ctx context.Context) (map[five9types
 		return q.Items, nil
 	}
 
+	return s.refreshQueueInfoMap(ctx, s.domainMetadataCache.queueInfoState.Generation())
+}
+
+func (s *SupervisorService) refreshQueueInfoMap(ctx context.Context, generation uint64) (map[five9types.QueueID]five9types.QueueInfo, error) {
 	queues, err := s.GetAllQueues(ctx)
 	if err != nil {
 		return nil, err
@@ -127,7 +150,7 @@ func (s *SupervisorService) getQueueInfoMap(ctx context.Context) (map[five9types
 		freshData[queue.ID] = queue
 	}
 
-	s.domainMetadataCache.queueInfoState.Replace(freshData)
+	s.domainMetadataCache.queueInfoState.ReplaceIfGeneration(generation, freshData)
 
 	return freshData, nil
 }
@@ -152,12 +175,62 @@ func (s *SupervisorService) GetAllQueues(ctx context.Context) ([]five9types.Queu
 	return target, nil
 }
 
+func (s *SupervisorService) getCampaignInfoMap(ctx context.Context) (map[five9types.CampaignID]five9types.CampaignInfo, error) {
+	c, err := s.domainMetadataCache.campaignInfoState.GetAll()
+	if err == nil {
+		return c.Items, nil
+	}
+
+	return s.refreshCampaignInfoMap(ctx, s.domainMetadataCache.campaignInfoState.Generation())
+}
+
+func (s *SupervisorService) refreshCampaignInfoMap(ctx context.Context, generation uint64) (map[five9types.CampaignID]five9types.CampaignInfo, error) {
+	campaigns, err := s.GetAllCampaigns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	freshData := map[five9types.CampaignID]five9types.CampaignInfo{}
+
+	for _, campaign := range campaigns {
+		freshData[campaign.ID] = campaign
+	}
+
+	s.domainMetadataCache.campaignInfoState.ReplaceIfGeneration(generation, freshData)
+
+	return freshData, nil
+}
+
+func (s *SupervisorService) GetAllCampaigns(ctx context.Context) ([]five9types.CampaignInfo, error) {
+	var target []five9types.CampaignInfo
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		"/supsvcs/rs/svc/orgs/:organizationID/campaigns",
+		http.NoBody,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authState.requestWithAuthentication(request, &target); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
 func (s *SupervisorService) GetReasonCodeInfoMap(ctx context.Context) (map[five9types.ReasonCodeID]five9types.ReasonCodeInfo, error) {
 	r, err := s.domainMetadataCache.reasonCodeInfoState.GetAll()
 	if err == nil {
 		return r.Items, nil
 	}
 
+	return s.refreshReasonCodeInfoMap(ctx, s.domainMetadataCache.reasonCodeInfoState.Generation())
+}
+
+func (s *SupervisorService) refreshReasonCodeInfoMap(ctx context.Context, generation uint64) (map[five9types.ReasonCodeID]five9types.ReasonCodeInfo, error) {
 	reasonCodes, err := s.GetAllReasonCodes(ctx)
 	if err != nil {
 		return nil, err
@@ -169,7 +242,7 @@ func (s *SupervisorService) GetReasonCodeInfoMap(ctx context.Context) (map[five9
 		freshData[reasonCode.ID] = reasonCode
 	}
 
-	s.domainMetadataCache.reasonCodeInfoState.Replace(freshData)
+	s.domainMetadataCache.reasonCodeInfoState.ReplaceIfGeneration(generation, freshData)
 
 	return freshData, nil
 }
@@ -250,6 +323,27 @@ func (s *SupervisorService) requestWebSocketFullStatistics(ctx context.Context)
 	return nil
 }
 
-func (s *SupervisorService) UpdateAgentState(ctx context.Context, agentID five9types.UserID) (five9types.UserFullStateInfo, error) {
-	return five9types.UserFullStateInfo{}, nil
+// UpdateAgentState changes the current and/or pending presence of another agent within the domain.
+func (s *SupervisorService) UpdateAgentState(
+	ctx context.Context,
+	agentID five9types.UserID,
+	stateUpdate five9types.UserStateUpdate,
+) (five9types.UserFullStateInfo, error) {
+	var target five9types.UserFullStateInfo
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		fmt.Sprintf("/supsvcs/rs/svc/supervisors/:userID/agents/%s/presence", agentID),
+		structToReaderCloser(stateUpdate),
+	)
+	if err != nil {
+		return five9types.UserFullStateInfo{}, err
+	}
+
+	if err := s.authState.requestWithAuthentication(request, &target); err != nil {
+		return five9types.UserFullStateInfo{}, err
+	}
+
+	return target, nil
 }
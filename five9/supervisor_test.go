@@ -0,0 +1,77 @@
+package five9_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+func Test_UpdateAgentState_Success(t *testing.T) {
+	ctx := context.Background()
+	madeAllExpectedAPICalls := false
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/agents/:agentID/presence
+				madeAllExpectedAPICalls = true
+
+				if r.Method != http.MethodPut {
+					t.Fatalf("expected method %s, got %s", http.MethodPut, r.Method)
+				}
+
+				expectedPath := "/supsvcs/rs/svc/supervisors/123456789/agents/300000000000001/presence"
+				if r.URL.Path != expectedPath {
+					t.Fatalf("expected path %s, got %s", expectedPath, r.URL.Path)
+				}
+
+				payload := five9types.UserStateUpdate{}
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Fatal(err)
+				}
+
+				if payload.PendingState == nil || payload.PendingState.NotReadyReasonCode != 300000000000002 {
+					t.Fatalf("expected pending state with not ready reason code, got %+v", payload.PendingState)
+				}
+
+				if payload.CurrentState != nil {
+					t.Fatalf("expected current state to be omitted, got %+v", payload.CurrentState)
+				}
+
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_updateAgentState_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+		),
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+	)
+
+	stateInfo, err := s.Supervisor().UpdateAgentState(ctx, "300000000000001", five9types.UserStateUpdate{
+		PendingState:   five9types.NotReadyState(300000000000002),
+		GracefulModeOn: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !madeAllExpectedAPICalls {
+		t.Fatalf("did not make all expected API calls - %d api requests remaining in queue", len(mockRoundTripper.Func))
+	}
+
+	if !stateInfo.IsGracefulModeOn {
+		t.Fatal("expected graceful mode to be on")
+	}
+
+	if stateInfo.PendingState.PendingState.NotReadyReasonCode != 300000000000002 {
+		t.Fatalf("expected pending not ready reason code to be parsed, got %d", stateInfo.PendingState.PendingState.NotReadyReasonCode)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/equalsgibson/concur/concur"
@@ -25,6 +28,30 @@ type supervisorWebSocketCache struct {
 		five9types.QueueID,
 		five9types.ACDState,
 	]
+	campaignState *utils.MemoryCacheInstance[
+		five9types.CampaignID,
+		five9types.WebSocketStatisticsCampaignStateData,
+	]
+	inboundCampaignStatistics *utils.MemoryCacheInstance[
+		five9types.CampaignID,
+		five9types.WebSocketStatisticsInboundCampaignStatisticsData,
+	]
+	outboundCampaignStatistics *utils.MemoryCacheInstance[
+		five9types.CampaignID,
+		five9types.WebSocketStatisticsOutboundCampaignStatisticsData,
+	]
+	outboundCampaignManager *utils.MemoryCacheInstance[
+		five9types.CampaignID,
+		five9types.WebSocketStatisticsOutboundCampaignManagerData,
+	]
+	userSessions *utils.MemoryCacheInstance[
+		five9types.SessionID,
+		five9types.WebSocketStatisticsUserSessionData,
+	]
+	stations *utils.MemoryCacheInstance[
+		five9types.StationID,
+		five9types.WebSocketStatisticsStationData,
+	]
 	timers *utils.MemoryCacheInstance[
 		five9types.EventID,
 		*time.Time,
@@ -32,15 +59,45 @@ type supervisorWebSocketCache struct {
 }
 
 func (s *SupervisorService) StartWebsocket(parentCtx context.Context) error {
+	s.reopenWebSocket()
+
+	return s.startWebsocket(parentCtx, webSocketHooks{})
+}
+
+// reopenWebSocket allows WebSocket connections to be made again after Logout.
+func (s *SupervisorService) reopenWebSocket() {
+	s.webSocketMutex.Lock()
+	defer s.webSocketMutex.Unlock()
+
+	s.webSocketClosed = false
+}
+
+// webSocketHooks are invoked by startWebsocket as the connection progresses. Any of the hooks may be nil.
+type webSocketHooks struct {
+	onConnected func()
+	onResynced  func()
+}
+
+func (s *SupervisorService) startWebsocket(parentCtx context.Context, hooks webSocketHooks) error {
 	// Clear any stale data from a previous connection
-	s.resetCache()
+	s.resetWebSocketCache()
 
 	// If we encounter an error on the WebsocketErr channel, cancel the context, thus cancelling all other goroutines.
 	ctx, cancel := context.WithCancelCause(parentCtx)
+	defer cancel(nil)
+
+	s.webSocketMutex.Lock()
+	if s.webSocketClosed {
+		s.webSocketMutex.Unlock()
+
+		return ErrWebSocketClosed
+	}
+	s.webSocketCancel = cancel
+	s.webSocketMutex.Unlock()
 
 	defer func() {
 		// Clear the cache when closing the connection
-		s.resetCache()
+		s.resetWebSocketCache()
 	}()
 
 	login, err := s.authState.getLogin(ctx)
@@ -48,13 +105,30 @@ func (s *SupervisorService) StartWebsocket(parentCtx context.Context) error {
 		return err
 	}
 
-	connectionURL := fmt.Sprintf("wss://%s/supsvcs/sws/%s", login.GetAPIHost(), uuid.NewString())
+	apiHost := s.authState.apiHost(login)
+	connectionURL := fmt.Sprintf("wss://%s/supsvcs/sws/%s", apiHost, uuid.NewString())
 
 	if err := s.webSocketHandler.Connect(ctx, connectionURL, s.authState.client.httpClient); err != nil {
+		s.logger().WarnContext(ctx, "five9 webSocket connection failed", "url", connectionURL, "error", err)
+
+		if isUnreachable(err) {
+			// Connect to the next API server on the next attempt.
+			s.authState.apiHosts.failover(apiHost)
+		} else if errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrForbidden) || errors.Is(err, ErrServiceMigrated) {
+			// The session has ended or moved, so log in again on the next attempt.
+			s.authState.clearLogin(login)
+		}
+
 		return err
 	}
 	defer s.webSocketHandler.Close()
 
+	s.logger().InfoContext(ctx, "five9 webSocket connected", "url", connectionURL, "user_id", login.UserID)
+
+	if hooks.onConnected != nil {
+		hooks.onConnected()
+	}
+
 	asyncReader := concur.NewAsyncReader(s.webSocketHandler.Read)
 	go asyncReader.Loop(ctx)
 	defer asyncReader.Close()
@@ -66,6 +140,7 @@ func (s *SupervisorService) StartWebsocket(parentCtx context.Context) error {
 			select {
 			case <-pingTicker.C:
 				if err := s.ping(ctx); err != nil {
+					s.logger().WarnContext(ctx, "five9 webSocket ping failed", "error", err)
 					cancel(err)
 					return
 				}
@@ -80,11 +155,11 @@ func (s *SupervisorService) StartWebsocket(parentCtx context.Context) error {
 	go func() {
 		for {
 			select {
-			case <-pingTicker.C:
+			case <-pongMonitorTicker.C:
 				if err := s.pong(ctx); err != nil {
+					s.logger().WarnContext(ctx, "five9 webSocket pong check failed", "error", err)
 					cancel(err)
 					return
-					// asyncReader.Close()
 				}
 			case <-ctx.Done():
 				return
@@ -97,21 +172,48 @@ func (s *SupervisorService) StartWebsocket(parentCtx context.Context) error {
 		// When starting a new session, this is called by Five9. Account for rejoining an existing session by also
 		// calling this.
 		if err := s.requestWebSocketFullStatistics(ctx); err != nil {
+			s.logger().WarnContext(ctx, "five9 webSocket full statistics request failed", "error", err)
 			cancel(err)
-			// asyncReader.Close()
 		}
 	}()
 
+	resynced := false
+
 	for {
 		select {
 		case update := <-asyncReader.Updates():
 			if update.Err != nil {
+				if cause := context.Cause(ctx); cause != nil {
+					// The read failed because the connection was closed on purpose, for example by Logout.
+					return cause
+				}
+
+				s.logger().WarnContext(ctx, "five9 webSocket read failed", "error", update.Err)
+
 				return update.Err
 			}
 
-			if err := s.handleWebsocketMessage(update.Item); err != nil {
+			if err := s.handleWebsocketMessage(ctx, update.Item); err != nil {
+				s.logger().ErrorContext(ctx, "five9 webSocket frame processing failed", "error", err)
+
+				if errors.Is(err, ErrWebSocketDuplicateConnection) {
+					// Another connection has taken over the session, so reconnecting needs a new login.
+					s.authState.clearLogin(login)
+				}
+
 				return err
 			}
+
+			if !resynced {
+				// The timers are reset with the cache, so a 5000 timer means a full snapshot arrived on this connection.
+				if _, ok := s.webSocketCache.timers.Get(five9types.EventIDSupervisorStats); ok {
+					resynced = true
+
+					if hooks.onResynced != nil {
+						hooks.onResynced()
+					}
+				}
+			}
 		case <-ctx.Done():
 			return context.Cause(ctx)
 		}
@@ -217,6 +319,287 @@ func (s *SupervisorService) WSACDState(ctx context.Context) (map[string]five9typ
 	return response, nil
 }
 
+func (s *SupervisorService) WSCampaignState(ctx context.Context) (map[string]five9types.WebSocketStatisticsCampaignStateData, error) {
+	response := map[string]five9types.WebSocketStatisticsCampaignStateData{}
+
+	campaigns, err := s.getCampaignInfoMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allCampaignState, err := s.webSocketCache.campaignState.GetAll()
+	if err != nil {
+		if errors.Is(err, utils.ErrWebSocketCacheStale) {
+			return nil, ErrWebSocketCacheStale
+		}
+
+		if errors.Is(err, utils.ErrWebSocketCacheNotReady) {
+			return nil, ErrWebSocketCacheNotReady
+		}
+
+		return nil, err
+	}
+
+	for campaignID, campaignState := range allCampaignState.Items {
+		campaignInfo, ok := campaigns[campaignID]
+		if !ok {
+			continue
+		}
+
+		response[campaignInfo.Name] = campaignState
+	}
+
+	return response, nil
+}
+
+func (s *SupervisorService) WSInboundCampaignStatistics(ctx context.Context) (map[string]five9types.WebSocketStatisticsInboundCampaignStatisticsData, error) {
+	response := map[string]five9types.WebSocketStatisticsInboundCampaignStatisticsData{}
+
+	campaigns, err := s.getCampaignInfoMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allCampaignStatistics, err := s.webSocketCache.inboundCampaignStatistics.GetAll()
+	if err != nil {
+		if errors.Is(err, utils.ErrWebSocketCacheStale) {
+			return nil, ErrWebSocketCacheStale
+		}
+
+		if errors.Is(err, utils.ErrWebSocketCacheNotReady) {
+			return nil, ErrWebSocketCacheNotReady
+		}
+
+		return nil, err
+	}
+
+	for campaignID, campaignStatistics := range allCampaignStatistics.Items {
+		campaignInfo, ok := campaigns[campaignID]
+		if !ok {
+			continue
+		}
+
+		response[campaignInfo.Name] = campaignStatistics
+	}
+
+	return response, nil
+}
+
+func (s *SupervisorService) WSOutboundCampaignStatistics(ctx context.Context) (map[string]five9types.WebSocketStatisticsOutboundCampaignStatisticsData, error) {
+	response := map[string]five9types.WebSocketStatisticsOutboundCampaignStatisticsData{}
+
+	campaigns, err := s.getCampaignInfoMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allCampaignStatistics, err := s.webSocketCache.outboundCampaignStatistics.GetAll()
+	if err != nil {
+		if errors.Is(err, utils.ErrWebSocketCacheStale) {
+			return nil, ErrWebSocketCacheStale
+		}
+
+		if errors.Is(err, utils.ErrWebSocketCacheNotReady) {
+			return nil, ErrWebSocketCacheNotReady
+		}
+
+		return nil, err
+	}
+
+	for campaignID, campaignStatistics := range allCampaignStatistics.Items {
+		campaignInfo, ok := campaigns[campaignID]
+		if !ok {
+			continue
+		}
+
+		response[campaignInfo.Name] = campaignStatistics
+	}
+
+	return response, nil
+}
+
+func (s *SupervisorService) WSOutboundCampaignManager(ctx context.Context) (map[string]five9types.WebSocketStatisticsOutboundCampaignManagerData, error) {
+	response := map[string]five9types.WebSocketStatisticsOutboundCampaignManagerData{}
+
+	campaigns, err := s.getCampaignInfoMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allCampaignManagers, err := s.webSocketCache.outboundCampaignManager.GetAll()
+	if err != nil {
+		if errors.Is(err, utils.ErrWebSocketCacheStale) {
+			return nil, ErrWebSocketCacheStale
+		}
+
+		if errors.Is(err, utils.ErrWebSocketCacheNotReady) {
+			return nil, ErrWebSocketCacheNotReady
+		}
+
+		return nil, err
+	}
+
+	for campaignID, campaignManager := range allCampaignManagers.Items {
+		campaignInfo, ok := campaigns[campaignID]
+		if !ok {
+			continue
+		}
+
+		response[campaignInfo.Name] = campaignManager
+	}
+
+	return response, nil
+}
+
+// WSUserSessions returns every live user session, such as logged in agents and supervisors, keyed by session ID.
+// Each session is joined with the domain user and, if known, the station it is using.
+func (s *SupervisorService) WSUserSessions(ctx context.Context) (map[five9types.SessionID]five9types.UserSessionInfo, error) {
+	response := map[five9types.SessionID]five9types.UserSessionInfo{}
+
+	domainUsers, err := s.getDomainUserInfoMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allUserSessions, err := s.webSocketCache.userSessions.GetAll()
+	if err != nil {
+		if errors.Is(err, utils.ErrWebSocketCacheStale) {
+			return nil, ErrWebSocketCacheStale
+		}
+
+		if errors.Is(err, utils.ErrWebSocketCacheNotReady) {
+			return nil, ErrWebSocketCacheNotReady
+		}
+
+		return nil, err
+	}
+
+	for sessionID, userSession := range allUserSessions.Items {
+		userInfo, ok := domainUsers[userSession.UserID]
+		if !ok {
+			// Keep sessions of users that are not in the metadata cache yet, they are still logged in.
+			userInfo = five9types.AgentInfo{
+				ID:       userSession.UserID,
+				UserName: userSession.UserName,
+			}
+		}
+
+		sessionInfo := five9types.UserSessionInfo{
+			Session: userSession,
+			User:    userInfo,
+		}
+
+		if userSession.Station != "" {
+			if station, ok := s.webSocketCache.stations.Get(userSession.Station); ok {
+				sessionInfo.Station = &station
+			}
+		}
+
+		response[sessionID] = sessionInfo
+	}
+
+	return response, nil
+}
+
+func (s *SupervisorService) WSStations(_ context.Context) (map[five9types.StationID]five9types.WebSocketStatisticsStationData, error) {
+	allStations, err := s.webSocketCache.stations.GetAll()
+	if err != nil {
+		if errors.Is(err, utils.ErrWebSocketCacheStale) {
+			return nil, ErrWebSocketCacheStale
+		}
+
+		if errors.Is(err, utils.ErrWebSocketCacheNotReady) {
+			return nil, ErrWebSocketCacheNotReady
+		}
+
+		return nil, err
+	}
+
+	return allStations.Items, nil
+}
+
+// WSResyncCount returns how many times a full statistics resynchronization was requested because incremental
+// updates arrived with a gap or out of order.
+func (s *SupervisorService) WSResyncCount() uint64 {
+	return s.webSocketSequence.resyncCount.Load()
+}
+
+// webSocketSequenceTracker follows the message counters of the WebSocket frames, so a lost or reordered frame can be
+// detected before the cache silently diverges from Five9. Five9 counts the frames broadcast to every supervisor, such
+// as incremental updates, separately from the frames sent to this user only, such as statistics snapshots: the
+// recorded snapshot is message 15641 and the recorded update that follows it is message 210977.
+type webSocketSequenceTracker struct {
+	mutex       *sync.Mutex
+	userFrames  messageSequence
+	broadcasts  messageSequence
+	resyncCount atomic.Uint64
+}
+
+// messageSequence is the last message ID of one of the counters of webSocketSequenceTracker.
+type messageSequence struct {
+	last        uint64
+	hasBaseline bool
+}
+
+// inSequence records the message ID and reports false if one or more messages of the same counter were missed or
+// arrived out of order. After a gap, the next message starts a new baseline.
+func (t *webSocketSequenceTracker) inSequence(messageContext five9types.WebsocketMessageContext) bool {
+	sequence, ok := messageContext.MessageID.Sequence()
+	if !ok {
+		return true
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	counter := &t.userFrames
+	if messageContext.BroadCast {
+		counter = &t.broadcasts
+	}
+
+	if !counter.hasBaseline {
+		*counter = messageSequence{last: sequence, hasBaseline: true}
+
+		return true
+	}
+
+	if sequence != counter.last+1 {
+		counter.hasBaseline = false
+
+		return false
+	}
+
+	counter.last = sequence
+
+	return true
+}
+
+// baseline makes the message ID of a full statistics snapshot the start of the counter of this user's frames. The
+// snapshot replaces anything that may have been missed before it, so the next broadcast starts a new baseline too.
+func (t *webSocketSequenceTracker) baseline(messageContext five9types.WebsocketMessageContext) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.broadcasts = messageSequence{}
+	t.userFrames = messageSequence{}
+
+	if sequence, ok := messageContext.MessageID.Sequence(); ok {
+		t.userFrames = messageSequence{last: sequence, hasBaseline: true}
+	}
+}
+
+func (t *webSocketSequenceTracker) reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.userFrames = messageSequence{}
+	t.broadcasts = messageSequence{}
+}
+
+func (s *SupervisorService) logger() *slog.Logger {
+	return s.authState.client.logger
+}
+
 func (s *SupervisorService) ping(ctx context.Context) error {
 	if err := s.webSocketHandler.Write(ctx, []byte("ping")); err != nil {
 		return err
@@ -238,19 +621,30 @@ func (s *SupervisorService) pong(_ context.Context) error {
 	return nil
 }
 
+// resetCache drops all cached data, as on logging out. The login is kept by the authenticationState.
 func (s *SupervisorService) resetCache() {
-	s.authState.loginMutex.Lock()
-	s.authState.loginResponse = nil
-	s.authState.loginMutex.Unlock()
+	s.resetWebSocketCache()
+
+	s.domainMetadataCache.agentInfoState.Reset()
+	s.domainMetadataCache.queueInfoState.Reset()
+	s.domainMetadataCache.reasonCodeInfoState.Reset()
+	s.domainMetadataCache.campaignInfoState.Reset()
+}
 
+// resetWebSocketCache drops the data received on a WebSocket connection. The domain metadata is not sent over
+// the WebSocket, so it stays cached across reconnects.
+func (s *SupervisorService) resetWebSocketCache() {
 	s.webSocketCache.acdState.Reset()
 	s.webSocketCache.agentState.Reset()
 	s.webSocketCache.agentStatistics.Reset()
+	s.webSocketCache.campaignState.Reset()
+	s.webSocketCache.inboundCampaignStatistics.Reset()
+	s.webSocketCache.outboundCampaignStatistics.Reset()
+	s.webSocketCache.outboundCampaignManager.Reset()
+	s.webSocketCache.userSessions.Reset()
+	s.webSocketCache.stations.Reset()
 	s.webSocketCache.timers.Reset()
-
-	s.domainMetadataCache.agentInfoState.Reset()
-	s.domainMetadataCache.queueInfoState.Reset()
-	s.domainMetadataCache.reasonCodeInfoState.Reset()
+	s.webSocketSequence.reset()
 
 	serviceReset := time.Now()
 	s.webSocketCache.timers.Update(five9types.EventIDPongReceived, &serviceReset)
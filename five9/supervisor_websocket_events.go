@@ -0,0 +1,261 @@
+package five9
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+// WebSocketEvent is implemented by every event delivered to a WebSocketSubscription.
+// Use a type switch to handle the events you are interested in.
+type WebSocketEvent interface {
+	webSocketEvent()
+}
+
+type WebSocketEventAction string
+
+const (
+	WebSocketEventActionAdded   WebSocketEventAction = "added"
+	WebSocketEventActionUpdated WebSocketEventAction = "updated"
+	WebSocketEventActionRemoved WebSocketEventAction = "removed"
+)
+
+// AgentStateEvent is published when an agent state is added, updated or removed by an incremental update.
+// For removals, State holds the last known state of the agent, if any.
+type AgentStateEvent struct {
+	Action  WebSocketEventAction
+	AgentID five9types.UserID
+	State   five9types.AgentState
+}
+
+// AgentStatisticsEvent is published when agent statistics are added, updated or removed by an incremental update.
+// For removals, Statistics holds the last known statistics of the agent, if any.
+type AgentStatisticsEvent struct {
+	Action     WebSocketEventAction
+	AgentID    five9types.UserID
+	Statistics five9types.AgentStatistics
+}
+
+// ACDStateEvent is published when a queue is added, updated or removed by an incremental update.
+// For removals, State holds the last known state of the queue, if any.
+type ACDStateEvent struct {
+	Action  WebSocketEventAction
+	QueueID five9types.QueueID
+	State   five9types.ACDState
+}
+
+// CampaignStateEvent is published when a campaign state is added, updated or removed by an incremental update.
+// For removals, State holds the last known state of the campaign, if any.
+type CampaignStateEvent struct {
+	Action     WebSocketEventAction
+	CampaignID five9types.CampaignID
+	State      five9types.WebSocketStatisticsCampaignStateData
+}
+
+// InboundCampaignStatisticsEvent is published when inbound campaign statistics are added, updated or removed by an
+// incremental update. For removals, Statistics holds the last known statistics of the campaign, if any.
+type InboundCampaignStatisticsEvent struct {
+	Action     WebSocketEventAction
+	CampaignID five9types.CampaignID
+	Statistics five9types.WebSocketStatisticsInboundCampaignStatisticsData
+}
+
+// OutboundCampaignStatisticsEvent is published when outbound campaign statistics are added, updated or removed by an
+// incremental update. For removals, Statistics holds the last known statistics of the campaign, if any.
+type OutboundCampaignStatisticsEvent struct {
+	Action     WebSocketEventAction
+	CampaignID five9types.CampaignID
+	Statistics five9types.WebSocketStatisticsOutboundCampaignStatisticsData
+}
+
+// OutboundCampaignManagerEvent is published when outbound campaign manager data is added, updated or removed by an
+// incremental update. For removals, Manager holds the last known data of the campaign, if any.
+type OutboundCampaignManagerEvent struct {
+	Action     WebSocketEventAction
+	CampaignID five9types.CampaignID
+	Manager    five9types.WebSocketStatisticsOutboundCampaignManagerData
+}
+
+// UserSessionEvent is published when a user session is added, updated or removed by an incremental update.
+// For removals, Session holds the last known data of the session, if any.
+type UserSessionEvent struct {
+	Action    WebSocketEventAction
+	SessionID five9types.SessionID
+	Session   five9types.WebSocketStatisticsUserSessionData
+}
+
+// StationEvent is published when a station is added, updated or removed by an incremental update.
+// For removals, Station holds the last known data of the station, if any.
+type StationEvent struct {
+	Action    WebSocketEventAction
+	StationID five9types.StationID
+	Station   five9types.WebSocketStatisticsStationData
+}
+
+// StatisticsSnapshotEvent is published after a full statistics snapshot (event 5000) replaced the cache for a data source.
+type StatisticsSnapshotEvent struct {
+	DataSource five9types.DataSource
+}
+
+// InvalidationEvent is published when Five9 reports that domain configuration, such as users or skills, has changed.
+type InvalidationEvent struct {
+	EventID five9types.EventID
+}
+
+func (AgentStateEvent) webSocketEvent()                 {}
+func (AgentStatisticsEvent) webSocketEvent()            {}
+func (ACDStateEvent) webSocketEvent()                   {}
+func (CampaignStateEvent) webSocketEvent()              {}
+func (InboundCampaignStatisticsEvent) webSocketEvent()  {}
+func (OutboundCampaignStatisticsEvent) webSocketEvent() {}
+func (OutboundCampaignManagerEvent) webSocketEvent()    {}
+func (UserSessionEvent) webSocketEvent()                {}
+func (StationEvent) webSocketEvent()                    {}
+func (StatisticsSnapshotEvent) webSocketEvent()         {}
+func (InvalidationEvent) webSocketEvent()               {}
+
+// SlowSubscriberPolicy decides what happens when a subscriber does not keep up with the WebSocket.
+// Events are never allowed to block the WebSocket reader.
+type SlowSubscriberPolicy int
+
+const (
+	// SlowSubscriberDrop discards events that do not fit in the subscription buffer. See WebSocketSubscription.Dropped.
+	SlowSubscriberDrop SlowSubscriberPolicy = iota
+	// SlowSubscriberDisconnect closes the subscription as soon as its buffer is full. See WebSocketSubscription.Err.
+	SlowSubscriberDisconnect
+)
+
+type WebSocketSubscription struct {
+	events    chan WebSocketEvent
+	policy    SlowSubscriberPolicy
+	broker    *webSocketEventBroker
+	dropped   atomic.Uint64
+	errMutex  *sync.Mutex
+	err       error
+	closeOnce *sync.Once
+}
+
+// Events returns the channel that events are delivered on. It is closed when the subscription ends.
+func (sub *WebSocketSubscription) Events() <-chan WebSocketEvent {
+	return sub.events
+}
+
+// Dropped returns the number of events discarded because the subscription buffer was full.
+func (sub *WebSocketSubscription) Dropped() uint64 {
+	return sub.dropped.Load()
+}
+
+// Err returns ErrSubscriberTooSlow if the subscription was closed by the SlowSubscriberDisconnect policy.
+func (sub *WebSocketSubscription) Err() error {
+	sub.errMutex.Lock()
+	defer sub.errMutex.Unlock()
+
+	return sub.err
+}
+
+// Unsubscribe stops the delivery of events and closes the Events channel. It is safe to call more than once.
+func (sub *WebSocketSubscription) Unsubscribe() {
+	sub.broker.remove(sub, nil)
+}
+
+// Subscribe registers a new subscriber for typed WebSocket events, buffering up to bufferSize events.
+// Subscriptions survive reconnects of the WebSocket and must be closed with Unsubscribe.
+func (s *SupervisorService) Subscribe(bufferSize int, policy SlowSubscriberPolicy) *WebSocketSubscription {
+	return s.webSocketEvents.add(bufferSize, policy)
+}
+
+// SubscribeFunc calls handler for every WebSocket event until the context is cancelled.
+// The handler runs on its own goroutine; events that arrive while the buffer is full are dropped.
+func (s *SupervisorService) SubscribeFunc(ctx context.Context, bufferSize int, handler func(WebSocketEvent)) {
+	sub := s.Subscribe(bufferSize, SlowSubscriberDrop)
+
+	go func() {
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case event, ok := <-sub.Events():
+				if !ok {
+					return
+				}
+
+				handler(event)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+type webSocketEventBroker struct {
+	mutex       *sync.RWMutex
+	subscribers map[*WebSocketSubscription]struct{}
+}
+
+func newWebSocketEventBroker() *webSocketEventBroker {
+	return &webSocketEventBroker{
+		mutex:       &sync.RWMutex{},
+		subscribers: map[*WebSocketSubscription]struct{}{},
+	}
+}
+
+func (b *webSocketEventBroker) add(bufferSize int, policy SlowSubscriberPolicy) *WebSocketSubscription {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+
+	sub := &WebSocketSubscription{
+		events:    make(chan WebSocketEvent, bufferSize),
+		policy:    policy,
+		broker:    b,
+		errMutex:  &sync.Mutex{},
+		closeOnce: &sync.Once{},
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.subscribers[sub] = struct{}{}
+
+	return sub
+}
+
+func (b *webSocketEventBroker) remove(sub *WebSocketSubscription, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	sub.closeOnce.Do(func() {
+		sub.errMutex.Lock()
+		sub.err = err
+		sub.errMutex.Unlock()
+
+		delete(b.subscribers, sub)
+		close(sub.events)
+	})
+}
+
+func (b *webSocketEventBroker) publish(event WebSocketEvent) {
+	tooSlow := []*WebSocketSubscription{}
+
+	b.mutex.RLock()
+	for sub := range b.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			if sub.policy == SlowSubscriberDisconnect {
+				tooSlow = append(tooSlow, sub)
+
+				continue
+			}
+
+			sub.dropped.Add(1)
+		}
+	}
+	b.mutex.RUnlock()
+
+	for _, sub := range tooSlow {
+		b.remove(sub, ErrSubscriberTooSlow)
+	}
+}
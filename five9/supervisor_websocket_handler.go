@@ -1,6 +1,7 @@
 package five9
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,7 +19,7 @@ func (err websocketFrameProcessingError) Error() string {
 	return fmt.Sprintf("Error while processing websocket frame: %s - %s", err.OriginalError.Error(), string(err.MessageBytes))
 }
 
-func (s *SupervisorService) handleWebsocketMessage(messageBytes []byte) error {
+func (s *SupervisorService) handleWebsocketMessage(ctx context.Context, messageBytes []byte) (err error) {
 	message := five9types.WebsocketMessage{}
 	if err := json.Unmarshal(messageBytes, &message); err != nil {
 		return websocketFrameProcessingError{
@@ -34,23 +35,71 @@ func (s *SupervisorService) handleWebsocketMessage(messageBytes []byte) error {
 		}
 	}
 
+	ctx, endFrame := s.authState.client.telemetry.startFrame(ctx, message.Context.EventID)
+	defer func() { endFrame(err) }()
+
+	s.logger().DebugContext(ctx, "five9 webSocket event",
+		"event_id", message.Context.EventID,
+		"message_id", message.Context.MessageID,
+	)
+
 	eventReceivedTime := time.Now()
 	s.webSocketCache.timers.Update(message.Context.EventID, &eventReceivedTime)
 
+	// The frame is still applied, as it holds the newest data, but anything in a missed frame is lost.
+	if message.Context.EventID == five9types.EventIDSupervisorStats {
+		s.webSocketSequence.baseline(message.Context)
+	} else if !s.webSocketSequence.inSequence(message.Context) {
+		s.resyncWebSocket(ctx, message.Context)
+	}
+
 	switch message.Context.EventID {
 	case five9types.EventIDServerConnected:
 		return nil
+	case five9types.EventIDDuplicateConnection:
+		// Another connection was opened for the same user, which closes this one.
+		s.logger().WarnContext(ctx, "five9 webSocket closed by a duplicate connection", "user_id", message.Context.UserID)
+
+		return ErrWebSocketDuplicateConnection
 	case five9types.EventIDPongReceived:
 		return s.handlerPong(message.Payload)
 	case five9types.EventIDIncrementalStatsUpdate:
-		return s.handlerIncrementalStatsUpdate(message.Payload)
+		return s.handlerIncrementalStatsUpdate(ctx, message.Payload)
 	case five9types.EventIDSupervisorStats:
-		return s.handlerSupervisorStats(message.Payload)
+		return s.handlerSupervisorStats(ctx, message.Payload)
+	case five9types.EventIDDispositionsInvalidated,
+		five9types.EventIDSkillsInvalidated,
+		five9types.EventIDAgentGroupsInvalidated,
+		five9types.EventIDCampaignsInvalidated,
+		five9types.EventIDUsersInvalidated,
+		five9types.EventIDReasonCodesInvalidated,
+		five9types.EventIDCampaignProfilesInvalidated,
+		five9types.EventIDListsInvalidated,
+		five9types.EventIDFilterSettingsUpdated,
+		five9types.EventIDAgentsInvalidated,
+		five9types.EventIDPermissionsUpdated:
+		return s.handlerInvalidation(ctx, message.Context.EventID)
 	}
 
 	return nil
 }
 
+// resyncWebSocket requests a full statistics snapshot in the background. If the request fails, the connection is kept,
+// and the next frame that is out of sequence tries again.
+func (s *SupervisorService) resyncWebSocket(ctx context.Context, messageContext five9types.WebsocketMessageContext) {
+	s.webSocketSequence.resyncCount.Add(1)
+	s.logger().WarnContext(ctx, "five9 webSocket frame out of sequence, requesting full statistics",
+		"event_id", messageContext.EventID,
+		"message_id", messageContext.MessageID,
+	)
+
+	go func() {
+		if err := s.requestWebSocketFullStatistics(ctx); err != nil {
+			s.logger().WarnContext(ctx, "five9 webSocket full statistics request failed", "error", err)
+		}
+	}()
+}
+
 func (s *SupervisorService) handlerPong(payload any) error {
 	payloadString, ok := payload.(string)
 	if !ok {
@@ -64,7 +113,55 @@ func (s *SupervisorService) handlerPong(payload any) error {
 	return nil
 }
 
-func (s *SupervisorService) handlerIncrementalStatsUpdate(payload any) error {
+func (s *SupervisorService) handlerInvalidation(ctx context.Context, eventID five9types.EventID) error {
+	// Drop the stale metadata straight away and refetch it in the background. If the refetch fails, the cache is left
+	// empty and the next read will fetch the metadata again. A refetch that finishes after a newer invalidation of the
+	// same cache is dropped, as it may hold data from before that invalidation.
+	s.logger().InfoContext(ctx, "five9 webSocket invalidation", "event_id", eventID)
+
+	switch eventID {
+	case five9types.EventIDUsersInvalidated, five9types.EventIDAgentsInvalidated:
+		s.domainMetadataCache.agentInfoState.Reset()
+		generation := s.domainMetadataCache.agentInfoState.Generation()
+		go func() {
+			if _, err := s.refreshDomainUserInfoMap(ctx, generation); err != nil {
+				s.logger().WarnContext(ctx, "five9 metadata refresh failed", "event_id", eventID, "error", err)
+			}
+		}()
+	case five9types.EventIDSkillsInvalidated:
+		s.domainMetadataCache.queueInfoState.Reset()
+		generation := s.domainMetadataCache.queueInfoState.Generation()
+		go func() {
+			if _, err := s.refreshQueueInfoMap(ctx, generation); err != nil {
+				s.logger().WarnContext(ctx, "five9 metadata refresh failed", "event_id", eventID, "error", err)
+			}
+		}()
+	case five9types.EventIDReasonCodesInvalidated:
+		s.domainMetadataCache.reasonCodeInfoState.Reset()
+		generation := s.domainMetadataCache.reasonCodeInfoState.Generation()
+		go func() {
+			if _, err := s.refreshReasonCodeInfoMap(ctx, generation); err != nil {
+				s.logger().WarnContext(ctx, "five9 metadata refresh failed", "event_id", eventID, "error", err)
+			}
+		}()
+	case five9types.EventIDCampaignsInvalidated:
+		s.domainMetadataCache.campaignInfoState.Reset()
+		generation := s.domainMetadataCache.campaignInfoState.Generation()
+		go func() {
+			if _, err := s.refreshCampaignInfoMap(ctx, generation); err != nil {
+				s.logger().WarnContext(ctx, "five9 metadata refresh failed", "event_id", eventID, "error", err)
+			}
+		}()
+	}
+
+	s.webSocketEvents.publish(InvalidationEvent{
+		EventID: eventID,
+	})
+
+	return nil
+}
+
+func (s *SupervisorService) handlerIncrementalStatsUpdate(ctx context.Context, payload any) error {
 	payloadSlice, ok := payload.([]any)
 	if !ok {
 		return fmt.Errorf("failed type assertion for payload: %T", payload)
@@ -87,6 +184,8 @@ func (s *SupervisorService) handlerIncrementalStatsUpdate(payload any) error {
 		}
 
 		dataSource := five9types.DataSource(dataSourceString)
+		s.logger().DebugContext(ctx, "five9 webSocket incremental update", "data_source", dataSource)
+		s.authState.client.telemetry.dataSourceUpdated(ctx, dataSource)
 
 		payloadItemBytes, err := json.Marshal(payloadItem)
 		if err != nil {
@@ -108,6 +207,19 @@ func (s *SupervisorService) handlerIncrementalStatsUpdate(payload any) error {
 				return err
 			}
 		// ** //
+		case five9types.DataSourceAgentStatistic:
+			eventTarget := five9types.WebSocketIncrementalAgentStatisticsData{}
+			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
+				return websocketFrameProcessingError{
+					OriginalError: err,
+					MessageBytes:  payloadItemBytes,
+				}
+			}
+
+			if err := s.handleAgentStatisticsUpdate(eventTarget); err != nil {
+				return err
+			}
+		// ** //
 		case five9types.DataSourceACDStatus:
 			eventTarget := five9types.WebSocketIncrementalACDStateData{}
 			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
@@ -120,13 +232,91 @@ func (s *SupervisorService) handlerIncrementalStatsUpdate(payload any) error {
 			if err := s.handleACDStateUpdate(eventTarget); err != nil {
 				return err
 			}
+		// ** //
+		case five9types.DataSourceCampaignState:
+			eventTarget := five9types.WebSocketIncrementalCampaignStateData{}
+			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
+				return websocketFrameProcessingError{
+					OriginalError: err,
+					MessageBytes:  payloadItemBytes,
+				}
+			}
+
+			if err := s.handleCampaignStateUpdate(eventTarget); err != nil {
+				return err
+			}
+		// ** //
+		case five9types.DataSourceInboundCampaignStatistics:
+			eventTarget := five9types.WebSocketIncrementalInboundCampaignStatisticsData{}
+			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
+				return websocketFrameProcessingError{
+					OriginalError: err,
+					MessageBytes:  payloadItemBytes,
+				}
+			}
+
+			if err := s.handleInboundCampaignStatisticsUpdate(eventTarget); err != nil {
+				return err
+			}
+		// ** //
+		case five9types.DataSourceOutboundCampaignStatistics:
+			eventTarget := five9types.WebSocketIncrementalOutboundCampaignStatisticsData{}
+			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
+				return websocketFrameProcessingError{
+					OriginalError: err,
+					MessageBytes:  payloadItemBytes,
+				}
+			}
+
+			if err := s.handleOutboundCampaignStatisticsUpdate(eventTarget); err != nil {
+				return err
+			}
+		// ** //
+		case five9types.DataSourceOutboundCampaignManager:
+			eventTarget := five9types.WebSocketIncrementalOutboundCampaignManagerData{}
+			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
+				return websocketFrameProcessingError{
+					OriginalError: err,
+					MessageBytes:  payloadItemBytes,
+				}
+			}
+
+			if err := s.handleOutboundCampaignManagerUpdate(eventTarget); err != nil {
+				return err
+			}
+		// ** //
+		case five9types.DataSourceUserSession:
+			eventTarget := five9types.WebSocketIncrementalUserSessionData{}
+			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
+				return websocketFrameProcessingError{
+					OriginalError: err,
+					MessageBytes:  payloadItemBytes,
+				}
+			}
+
+			if err := s.handleUserSessionUpdate(eventTarget); err != nil {
+				return err
+			}
+		// ** //
+		case five9types.DataSourceStations:
+			eventTarget := five9types.WebSocketIncrementalStationData{}
+			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
+				return websocketFrameProcessingError{
+					OriginalError: err,
+					MessageBytes:  payloadItemBytes,
+				}
+			}
+
+			if err := s.handleStationUpdate(eventTarget); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
-func (s *SupervisorService) handlerSupervisorStats(payload any) error {
+func (s *SupervisorService) handlerSupervisorStats(ctx context.Context, payload any) error {
 	payloadSlice, ok := payload.([]any)
 	if !ok {
 		return fmt.Errorf("failed type assertion for payload: %T", payload)
@@ -149,6 +339,8 @@ func (s *SupervisorService) handlerSupervisorStats(payload any) error {
 		}
 
 		dataSource := five9types.DataSource(dataSourceString)
+		s.logger().DebugContext(ctx, "five9 webSocket statistics snapshot", "data_source", dataSource)
+		s.authState.client.telemetry.dataSourceUpdated(ctx, dataSource)
 
 		payloadItemBytes, err := json.Marshal(payloadItem)
 		if err != nil {
@@ -173,6 +365,9 @@ func (s *SupervisorService) handlerSupervisorStats(payload any) error {
 			}
 
 			s.webSocketCache.agentState.Replace(freshData)
+			s.webSocketEvents.publish(StatisticsSnapshotEvent{
+				DataSource: dataSource,
+			})
 		// ** //
 		case five9types.DataSourceAgentStatistic:
 			eventTarget := five9types.WebsocketSupervisorStatisticsData{}
@@ -190,6 +385,9 @@ func (s *SupervisorService) handlerSupervisorStats(payload any) error {
 			}
 
 			s.webSocketCache.agentStatistics.Replace(freshData)
+			s.webSocketEvents.publish(StatisticsSnapshotEvent{
+				DataSource: dataSource,
+			})
 		// ** //
 		case five9types.DataSourceACDStatus:
 			eventTarget := five9types.WebsocketSupervisorACDData{}
@@ -206,6 +404,123 @@ func (s *SupervisorService) handlerSupervisorStats(payload any) error {
 			}
 
 			s.webSocketCache.acdState.Replace(freshData)
+			s.webSocketEvents.publish(StatisticsSnapshotEvent{
+				DataSource: dataSource,
+			})
+		// ** //
+		case five9types.DataSourceCampaignState:
+			eventTarget := five9types.WebsocketSupervisorCampaignStateData{}
+			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
+				return websocketFrameProcessingError{
+					OriginalError: err,
+					MessageBytes:  payloadItemBytes,
+				}
+			}
+
+			freshData := map[five9types.CampaignID]five9types.WebSocketStatisticsCampaignStateData{}
+			for _, campaign := range eventTarget.Data {
+				freshData[campaign.ID] = campaign
+			}
+
+			s.webSocketCache.campaignState.Replace(freshData)
+			s.webSocketEvents.publish(StatisticsSnapshotEvent{
+				DataSource: dataSource,
+			})
+		// ** //
+		case five9types.DataSourceInboundCampaignStatistics:
+			eventTarget := five9types.WebsocketSupervisorInboundCampaignStatisticsData{}
+			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
+				return websocketFrameProcessingError{
+					OriginalError: err,
+					MessageBytes:  payloadItemBytes,
+				}
+			}
+
+			freshData := map[five9types.CampaignID]five9types.WebSocketStatisticsInboundCampaignStatisticsData{}
+			for _, campaign := range eventTarget.Data {
+				freshData[campaign.ID] = campaign
+			}
+
+			s.webSocketCache.inboundCampaignStatistics.Replace(freshData)
+			s.webSocketEvents.publish(StatisticsSnapshotEvent{
+				DataSource: dataSource,
+			})
+		// ** //
+		case five9types.DataSourceOutboundCampaignStatistics:
+			eventTarget := five9types.WebsocketSupervisorOutboundCampaignStatisticsData{}
+			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
+				return websocketFrameProcessingError{
+					OriginalError: err,
+					MessageBytes:  payloadItemBytes,
+				}
+			}
+
+			freshData := map[five9types.CampaignID]five9types.WebSocketStatisticsOutboundCampaignStatisticsData{}
+			for _, campaign := range eventTarget.Data {
+				freshData[campaign.ID] = campaign
+			}
+
+			s.webSocketCache.outboundCampaignStatistics.Replace(freshData)
+			s.webSocketEvents.publish(StatisticsSnapshotEvent{
+				DataSource: dataSource,
+			})
+		// ** //
+		case five9types.DataSourceOutboundCampaignManager:
+			eventTarget := five9types.WebsocketSupervisorOutboundCampaignManagerData{}
+			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
+				return websocketFrameProcessingError{
+					OriginalError: err,
+					MessageBytes:  payloadItemBytes,
+				}
+			}
+
+			freshData := map[five9types.CampaignID]five9types.WebSocketStatisticsOutboundCampaignManagerData{}
+			for _, campaign := range eventTarget.Data {
+				freshData[campaign.ID] = campaign
+			}
+
+			s.webSocketCache.outboundCampaignManager.Replace(freshData)
+			s.webSocketEvents.publish(StatisticsSnapshotEvent{
+				DataSource: dataSource,
+			})
+		// ** //
+		case five9types.DataSourceUserSession:
+			eventTarget := five9types.WebsocketSupervisorUserSessionData{}
+			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
+				return websocketFrameProcessingError{
+					OriginalError: err,
+					MessageBytes:  payloadItemBytes,
+				}
+			}
+
+			freshData := map[five9types.SessionID]five9types.WebSocketStatisticsUserSessionData{}
+			for _, userSession := range eventTarget.Data {
+				freshData[userSession.ID] = userSession
+			}
+
+			s.webSocketCache.userSessions.Replace(freshData)
+			s.webSocketEvents.publish(StatisticsSnapshotEvent{
+				DataSource: dataSource,
+			})
+		// ** //
+		case five9types.DataSourceStations:
+			eventTarget := five9types.WebsocketSupervisorStationData{}
+			if err := json.Unmarshal(payloadItemBytes, &eventTarget); err != nil {
+				return websocketFrameProcessingError{
+					OriginalError: err,
+					MessageBytes:  payloadItemBytes,
+				}
+			}
+
+			freshData := map[five9types.StationID]five9types.WebSocketStatisticsStationData{}
+			for _, station := range eventTarget.Data {
+				freshData[station.ID] = station
+			}
+
+			s.webSocketCache.stations.Replace(freshData)
+			s.webSocketEvents.publish(StatisticsSnapshotEvent{
+				DataSource: dataSource,
+			})
 		}
 	}
 
@@ -215,14 +530,62 @@ func (s *SupervisorService) handlerSupervisorStats(payload any) error {
 func (s *SupervisorService) handleAgentStateUpdate(eventData five9types.WebSocketIncrementalAgentStateData) error {
 	for _, addedData := range eventData.Added {
 		s.webSocketCache.agentState.Update(addedData.ID, addedData)
+		s.webSocketEvents.publish(AgentStateEvent{
+			Action:  WebSocketEventActionAdded,
+			AgentID: addedData.ID,
+			State:   addedData,
+		})
 	}
 
 	for _, updatedData := range eventData.Updated {
 		s.webSocketCache.agentState.Update(updatedData.ID, updatedData)
+		s.webSocketEvents.publish(AgentStateEvent{
+			Action:  WebSocketEventActionUpdated,
+			AgentID: updatedData.ID,
+			State:   updatedData,
+		})
 	}
 
 	for _, removedID := range eventData.Removed {
+		lastKnownState, _ := s.webSocketCache.agentState.Get(removedID)
 		s.webSocketCache.agentState.Delete(removedID)
+		s.webSocketEvents.publish(AgentStateEvent{
+			Action:  WebSocketEventActionRemoved,
+			AgentID: removedID,
+			State:   lastKnownState,
+		})
+	}
+
+	return nil
+}
+
+func (s *SupervisorService) handleAgentStatisticsUpdate(eventData five9types.WebSocketIncrementalAgentStatisticsData) error {
+	for _, addedData := range eventData.Added {
+		s.webSocketCache.agentStatistics.Update(addedData.ID, addedData)
+		s.webSocketEvents.publish(AgentStatisticsEvent{
+			Action:     WebSocketEventActionAdded,
+			AgentID:    addedData.ID,
+			Statistics: addedData,
+		})
+	}
+
+	for _, updatedData := range eventData.Updated {
+		s.webSocketCache.agentStatistics.Update(updatedData.ID, updatedData)
+		s.webSocketEvents.publish(AgentStatisticsEvent{
+			Action:     WebSocketEventActionUpdated,
+			AgentID:    updatedData.ID,
+			Statistics: updatedData,
+		})
+	}
+
+	for _, removedID := range eventData.Removed {
+		lastKnownStatistics, _ := s.webSocketCache.agentStatistics.Get(removedID)
+		s.webSocketCache.agentStatistics.Delete(removedID)
+		s.webSocketEvents.publish(AgentStatisticsEvent{
+			Action:     WebSocketEventActionRemoved,
+			AgentID:    removedID,
+			Statistics: lastKnownStatistics,
+		})
 	}
 
 	return nil
@@ -231,14 +594,222 @@ func (s *SupervisorService) handleAgentStateUpdate(eventData five9types.WebSocke
 func (s *SupervisorService) handleACDStateUpdate(eventData five9types.WebSocketIncrementalACDStateData) error {
 	for _, addedData := range eventData.Added {
 		s.webSocketCache.acdState.Update(addedData.ID, addedData)
+		s.webSocketEvents.publish(ACDStateEvent{
+			Action:  WebSocketEventActionAdded,
+			QueueID: addedData.ID,
+			State:   addedData,
+		})
 	}
 
 	for _, updatedData := range eventData.Updated {
 		s.webSocketCache.acdState.Update(updatedData.ID, updatedData)
+		s.webSocketEvents.publish(ACDStateEvent{
+			Action:  WebSocketEventActionUpdated,
+			QueueID: updatedData.ID,
+			State:   updatedData,
+		})
 	}
 
 	for _, removedID := range eventData.Removed {
+		lastKnownState, _ := s.webSocketCache.acdState.Get(removedID)
 		s.webSocketCache.acdState.Delete(removedID)
+		s.webSocketEvents.publish(ACDStateEvent{
+			Action:  WebSocketEventActionRemoved,
+			QueueID: removedID,
+			State:   lastKnownState,
+		})
+	}
+
+	return nil
+}
+
+func (s *SupervisorService) handleCampaignStateUpdate(eventData five9types.WebSocketIncrementalCampaignStateData) error {
+	for _, addedData := range eventData.Added {
+		s.webSocketCache.campaignState.Update(addedData.ID, addedData)
+		s.webSocketEvents.publish(CampaignStateEvent{
+			Action:     WebSocketEventActionAdded,
+			CampaignID: addedData.ID,
+			State:      addedData,
+		})
+	}
+
+	for _, updatedData := range eventData.Updated {
+		s.webSocketCache.campaignState.Update(updatedData.ID, updatedData)
+		s.webSocketEvents.publish(CampaignStateEvent{
+			Action:     WebSocketEventActionUpdated,
+			CampaignID: updatedData.ID,
+			State:      updatedData,
+		})
+	}
+
+	for _, removedID := range eventData.Removed {
+		lastKnownState, _ := s.webSocketCache.campaignState.Get(removedID)
+		s.webSocketCache.campaignState.Delete(removedID)
+		s.webSocketEvents.publish(CampaignStateEvent{
+			Action:     WebSocketEventActionRemoved,
+			CampaignID: removedID,
+			State:      lastKnownState,
+		})
+	}
+
+	return nil
+}
+
+func (s *SupervisorService) handleInboundCampaignStatisticsUpdate(eventData five9types.WebSocketIncrementalInboundCampaignStatisticsData) error {
+	for _, addedData := range eventData.Added {
+		s.webSocketCache.inboundCampaignStatistics.Update(addedData.ID, addedData)
+		s.webSocketEvents.publish(InboundCampaignStatisticsEvent{
+			Action:     WebSocketEventActionAdded,
+			CampaignID: addedData.ID,
+			Statistics: addedData,
+		})
+	}
+
+	for _, updatedData := range eventData.Updated {
+		s.webSocketCache.inboundCampaignStatistics.Update(updatedData.ID, updatedData)
+		s.webSocketEvents.publish(InboundCampaignStatisticsEvent{
+			Action:     WebSocketEventActionUpdated,
+			CampaignID: updatedData.ID,
+			Statistics: updatedData,
+		})
+	}
+
+	for _, removedID := range eventData.Removed {
+		lastKnownStatistics, _ := s.webSocketCache.inboundCampaignStatistics.Get(removedID)
+		s.webSocketCache.inboundCampaignStatistics.Delete(removedID)
+		s.webSocketEvents.publish(InboundCampaignStatisticsEvent{
+			Action:     WebSocketEventActionRemoved,
+			CampaignID: removedID,
+			Statistics: lastKnownStatistics,
+		})
+	}
+
+	return nil
+}
+
+func (s *SupervisorService) handleOutboundCampaignStatisticsUpdate(eventData five9types.WebSocketIncrementalOutboundCampaignStatisticsData) error {
+	for _, addedData := range eventData.Added {
+		s.webSocketCache.outboundCampaignStatistics.Update(addedData.ID, addedData)
+		s.webSocketEvents.publish(OutboundCampaignStatisticsEvent{
+			Action:     WebSocketEventActionAdded,
+			CampaignID: addedData.ID,
+			Statistics: addedData,
+		})
+	}
+
+	for _, updatedData := range eventData.Updated {
+		s.webSocketCache.outboundCampaignStatistics.Update(updatedData.ID, updatedData)
+		s.webSocketEvents.publish(OutboundCampaignStatisticsEvent{
+			Action:     WebSocketEventActionUpdated,
+			CampaignID: updatedData.ID,
+			Statistics: updatedData,
+		})
+	}
+
+	for _, removedID := range eventData.Removed {
+		lastKnownStatistics, _ := s.webSocketCache.outboundCampaignStatistics.Get(removedID)
+		s.webSocketCache.outboundCampaignStatistics.Delete(removedID)
+		s.webSocketEvents.publish(OutboundCampaignStatisticsEvent{
+			Action:     WebSocketEventActionRemoved,
+			CampaignID: removedID,
+			Statistics: lastKnownStatistics,
+		})
+	}
+
+	return nil
+}
+
+func (s *SupervisorService) handleOutboundCampaignManagerUpdate(eventData five9types.WebSocketIncrementalOutboundCampaignManagerData) error {
+	for _, addedData := range eventData.Added {
+		s.webSocketCache.outboundCampaignManager.Update(addedData.ID, addedData)
+		s.webSocketEvents.publish(OutboundCampaignManagerEvent{
+			Action:     WebSocketEventActionAdded,
+			CampaignID: addedData.ID,
+			Manager:    addedData,
+		})
+	}
+
+	for _, updatedData := range eventData.Updated {
+		s.webSocketCache.outboundCampaignManager.Update(updatedData.ID, updatedData)
+		s.webSocketEvents.publish(OutboundCampaignManagerEvent{
+			Action:     WebSocketEventActionUpdated,
+			CampaignID: updatedData.ID,
+			Manager:    updatedData,
+		})
+	}
+
+	for _, removedID := range eventData.Removed {
+		lastKnownManager, _ := s.webSocketCache.outboundCampaignManager.Get(removedID)
+		s.webSocketCache.outboundCampaignManager.Delete(removedID)
+		s.webSocketEvents.publish(OutboundCampaignManagerEvent{
+			Action:     WebSocketEventActionRemoved,
+			CampaignID: removedID,
+			Manager:    lastKnownManager,
+		})
+	}
+
+	return nil
+}
+
+func (s *SupervisorService) handleUserSessionUpdate(eventData five9types.WebSocketIncrementalUserSessionData) error {
+	for _, addedData := range eventData.Added {
+		s.webSocketCache.userSessions.Update(addedData.ID, addedData)
+		s.webSocketEvents.publish(UserSessionEvent{
+			Action:    WebSocketEventActionAdded,
+			SessionID: addedData.ID,
+			Session:   addedData,
+		})
+	}
+
+	for _, updatedData := range eventData.Updated {
+		s.webSocketCache.userSessions.Update(updatedData.ID, updatedData)
+		s.webSocketEvents.publish(UserSessionEvent{
+			Action:    WebSocketEventActionUpdated,
+			SessionID: updatedData.ID,
+			Session:   updatedData,
+		})
+	}
+
+	for _, removedID := range eventData.Removed {
+		lastKnownSession, _ := s.webSocketCache.userSessions.Get(removedID)
+		s.webSocketCache.userSessions.Delete(removedID)
+		s.webSocketEvents.publish(UserSessionEvent{
+			Action:    WebSocketEventActionRemoved,
+			SessionID: removedID,
+			Session:   lastKnownSession,
+		})
+	}
+
+	return nil
+}
+
+func (s *SupervisorService) handleStationUpdate(eventData five9types.WebSocketIncrementalStationData) error {
+	for _, addedData := range eventData.Added {
+		s.webSocketCache.stations.Update(addedData.ID, addedData)
+		s.webSocketEvents.publish(StationEvent{
+			Action:    WebSocketEventActionAdded,
+			StationID: addedData.ID,
+			Station:   addedData,
+		})
+	}
+
+	for _, updatedData := range eventData.Updated {
+		s.webSocketCache.stations.Update(updatedData.ID, updatedData)
+		s.webSocketEvents.publish(StationEvent{
+			Action:    WebSocketEventActionUpdated,
+			StationID: updatedData.ID,
+			Station:   updatedData,
+		})
+	}
+
+	for _, removedID := range eventData.Removed {
+		lastKnownStation, _ := s.webSocketCache.stations.Get(removedID)
+		s.webSocketCache.stations.Delete(removedID)
+		s.webSocketEvents.publish(StationEvent{
+			Action:    WebSocketEventActionRemoved,
+			StationID: removedID,
+			Station:   lastKnownStation,
+		})
 	}
 
 	return nil
@@ -0,0 +1,131 @@
+package five9
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9/five9types"
+)
+
+// WebsocketRunnerConfig controls how RunWebsocket keeps the supervisor WebSocket connection alive.
+// Zero values are replaced with sensible defaults.
+type WebsocketRunnerConfig struct {
+	InitialBackoff time.Duration // Delay before the first reconnect attempt. Defaults to 1 second.
+	MaxBackoff     time.Duration // Upper bound for the delay between reconnect attempts. Defaults to 1 minute.
+	Multiplier     float64       // Growth factor applied to the delay after each failed attempt. Defaults to 2.
+	Jitter         float64       // Fraction (0-1) of the delay that is randomised to spread out reconnects. Defaults to 0.2.
+	DisableJitter  bool          // Waits exactly the computed delay, ignoring Jitter.
+	MaxAttempts    int           // Consecutive failed attempts before giving up. Zero retries forever.
+
+	OnConnected    func()          // Called each time the WebSocket connection is established.
+	OnDisconnected func(err error) // Called each time a connection attempt fails or an established connection drops.
+	OnResynced     func()          // Called once the full statistics snapshot has been received on a connection.
+}
+
+// RunWebsocket starts the supervisor WebSocket and transparently reconnects when the connection drops,
+// for example after a 435 service migration, a pong timeout or a network error.
+// It only returns when the context is cancelled, Logout is called, or once MaxAttempts consecutive attempts have failed.
+// A connection counts as healthy, and resets the attempt counter, once the full statistics snapshot has been received.
+//
+// When another connection takes over the session (ErrWebSocketDuplicateConnection), RunWebsocket yields and returns the
+// error if the login policy is five9types.PolicyAttachExisting, and takes the session back if it is five9types.PolicyForceIn.
+func (s *SupervisorService) RunWebsocket(ctx context.Context, config WebsocketRunnerConfig) error {
+	config = config.withDefaults()
+
+	s.reopenWebSocket()
+
+	backoff := config.InitialBackoff
+	failedAttempts := 0
+
+	for {
+		healthy := false
+
+		err := s.startWebsocket(ctx, webSocketHooks{
+			onConnected: config.OnConnected,
+			onResynced: func() {
+				healthy = true
+
+				if config.OnResynced != nil {
+					config.OnResynced()
+				}
+			},
+		})
+
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+
+		if errors.Is(err, ErrWebSocketClosed) {
+			return err
+		}
+
+		s.logger().WarnContext(ctx, "five9 webSocket disconnected", "attempt", failedAttempts+1, "error", err)
+
+		if config.OnDisconnected != nil {
+			config.OnDisconnected(err)
+		}
+
+		if errors.Is(err, ErrWebSocketDuplicateConnection) && s.authState.client.loginPolicy == five9types.PolicyAttachExisting {
+			return err
+		}
+
+		if healthy {
+			failedAttempts = 0
+			backoff = config.InitialBackoff
+		}
+
+		failedAttempts++
+		if config.MaxAttempts > 0 && failedAttempts >= config.MaxAttempts {
+			return fmt.Errorf("%w: %w", ErrWebSocketMaxAttemptsReached, err)
+		}
+
+		timer := time.NewTimer(config.jitter(backoff))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+
+			return context.Cause(ctx)
+		}
+
+		backoff = time.Duration(float64(backoff) * config.Multiplier)
+		if backoff > config.MaxBackoff {
+			backoff = config.MaxBackoff
+		}
+	}
+}
+
+func (config WebsocketRunnerConfig) withDefaults() WebsocketRunnerConfig {
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = time.Second
+	}
+
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = time.Minute
+	}
+
+	if config.MaxBackoff < config.InitialBackoff {
+		config.MaxBackoff = config.InitialBackoff
+	}
+
+	if config.Multiplier < 1 {
+		config.Multiplier = 2
+	}
+
+	if config.DisableJitter {
+		config.Jitter = 0
+	} else if config.Jitter <= 0 || config.Jitter > 1 {
+		config.Jitter = 0.2
+	}
+
+	return config
+}
+
+func (config WebsocketRunnerConfig) jitter(delay time.Duration) time.Duration {
+	spread := float64(delay) * config.Jitter
+
+	return delay - time.Duration(spread) + time.Duration(rand.Float64()*2*spread)
+}
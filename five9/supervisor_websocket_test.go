@@ -2,164 +2,1253 @@ package five9_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
-	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9test"
+	"github.com/equalsgibson/five9-go/five9/five9types"
 )
 
 type MockRoundTripper struct {
-	Func []func(r *http.Request) (*http.Response, error)
+	Func  []func(r *http.Request) (*http.Response, error)
+	mutex sync.Mutex
 }
 
 // Roundtrip is the "mock" responses from the server.
 func (mock *MockRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
-	// Do switch statement here, not
+	// The WebSocket sends some requests in the background, so the queue can be used concurrently.
+	mock.mutex.Lock()
 	if len(mock.Func) == 0 {
+		mock.mutex.Unlock()
+
 		return nil, errors.New("end of queue")
 	}
 
 	response := mock.Func[0]
 	mock.Func = mock.Func[1:]
+	mock.mutex.Unlock()
 
 	return response(r)
 }
 
-type MockWebsocketHandler struct {
-	ConnectionError error
-	clientQueue     chan []byte
-	// serverQueue       chan []byte
-	checkFrameContent func(data []byte)
+func Test_GetInternalCache_Success(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fullStatisticsRequested := make(chan struct{})
+
+	mockWebsocket := five9test.NewWebSocketHandler()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(mockWebsocket),
+		five9.SetRoundTripper(&MockRoundTripper{
+			Func: append(
+				generateLoginRequestFuncs(t),
+				func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/request_full_statistics
+					close(fullStatisticsRequested)
+
+					return &http.Response{
+						Body:       http.NoBody,
+						StatusCode: http.StatusNoContent,
+					}, nil
+				},
+				func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+					return &http.Response{
+						Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+						StatusCode: http.StatusOK,
+					}, nil
+				},
+			),
+		}),
+	)
+
+	subscription := s.Supervisor().Subscribe(10, five9.SlowSubscriberDrop)
+	defer subscription.Unsubscribe()
+
+	go func() {
+		_ = s.Supervisor().StartWebsocket(ctx)
+	}()
+
+	select {
+	case <-fullStatisticsRequested:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for full statistics request")
+	}
+
+	if err := mockWebsocket.SendServerConnected(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mockWebsocket.SendStatistics(ctx, five9test.StatisticsSnapshot{
+		DataSource: five9types.DataSourceAgentState,
+		Data: []five9types.AgentState{
+			{ID: "123456789", State: five9types.UserStateReady},
+			{ID: "345123789", State: five9types.UserStateLoggedOut},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mockWebsocket.SendIncrementalUpdate(ctx, five9test.IncrementalUpdate{
+		DataSource: five9types.DataSourceAgentState,
+		Updated: []five9types.AgentState{
+			{ID: "345123789", State: five9types.UserStateNotReady},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for the incremental update, which is the last change sent.
+	for waiting := true; waiting; {
+		select {
+		case event := <-subscription.Events():
+			if _, ok := event.(five9.AgentStateEvent); ok {
+				waiting = false
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for agent state update")
+		}
+	}
+
+	agents, err := s.Supervisor().WSAgentState(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(agents) != 2 {
+		t.Fatalf("expected 2 agents in internal cache, got %d", len(agents))
+	}
+
+	if agents["aaron.ellington@example.com"].State != five9types.UserStateNotReady {
+		t.Fatalf("expected agent to be not ready, got %s", agents["aaron.ellington@example.com"].State)
+	}
+
+	if len(mockWebsocket.ConnectionURLs()) != 1 {
+		t.Fatalf("expected 1 websocket connection, got %d", len(mockWebsocket.ConnectionURLs()))
+	}
+}
+
+func Test_WebsocketSubscription_ReceivesTypedEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockWebsocket := five9test.NewWebSocketHandler()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(mockWebsocket),
+		five9.SetRoundTripper(&MockRoundTripper{
+			Func: generateWSStartRequestFuncs(t),
+		}),
+	)
+
+	subscription := s.Supervisor().Subscribe(100, five9.SlowSubscriberDrop)
+	defer subscription.Unsubscribe()
+
+	websocketErr := make(chan error, 1)
+	go func() {
+		websocketErr <- s.Supervisor().StartWebsocket(ctx)
+	}()
+
+	if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/1010_successfulWebSocketConnection.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/5000_stats.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/5012_incrementalStatsUpdate_removed.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedEvents := []five9.WebSocketEvent{
+		five9.StatisticsSnapshotEvent{DataSource: five9types.DataSourceAgentState},
+		five9.AgentStateEvent{Action: five9.WebSocketEventActionRemoved, AgentID: "123456789"},
+		five9.AgentStateEvent{Action: five9.WebSocketEventActionRemoved, AgentID: "987654321"},
+	}
+
+	for _, expectedEvent := range expectedEvents {
+		select {
+		case event := <-subscription.Events():
+			switch expected := expectedEvent.(type) {
+			case five9.StatisticsSnapshotEvent:
+				if event != expected {
+					t.Fatalf("expected %+v, got %+v", expected, event)
+				}
+			case five9.AgentStateEvent:
+				agentStateEvent, ok := event.(five9.AgentStateEvent)
+				if !ok {
+					t.Fatalf("expected AgentStateEvent, got %T", event)
+				}
+
+				if agentStateEvent.Action != expected.Action || agentStateEvent.AgentID != expected.AgentID {
+					t.Fatalf("expected %s for agent %s, got %s for agent %s", expected.Action, expected.AgentID, agentStateEvent.Action, agentStateEvent.AgentID)
+				}
+			}
+		case err := <-websocketErr:
+			t.Fatalf("websocket exited early: %v", err)
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for websocket event")
+		}
+	}
+
+	if subscription.Dropped() != 0 {
+		t.Fatalf("expected no dropped events, got %d", subscription.Dropped())
+	}
+
+	cancel()
+
+	if err := <-websocketErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func Test_WebsocketSubscription_SlowSubscriberDisconnected(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockWebsocket := five9test.NewWebSocketHandler()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(mockWebsocket),
+		five9.SetRoundTripper(&MockRoundTripper{
+			Func: generateWSStartRequestFuncs(t),
+		}),
+	)
+
+	subscription := s.Supervisor().Subscribe(1, five9.SlowSubscriberDisconnect)
+
+	go func() {
+		_ = s.Supervisor().StartWebsocket(ctx)
+	}()
+
+	// The removed frame produces two events, which overflows the buffer of one.
+	if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/5012_incrementalStatsUpdate_removed.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Do not read from the subscription, so the second event cannot fit in the buffer.
+	deadline := time.Now().Add(time.Second * 5)
+	for subscription.Err() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for subscription to be closed")
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	if !errors.Is(subscription.Err(), five9.ErrSubscriberTooSlow) {
+		t.Fatalf("expected ErrSubscriberTooSlow, got %v", subscription.Err())
+	}
+
+	if _, ok := <-subscription.Events(); !ok {
+		t.Fatal("expected the buffered event to still be readable")
+	}
+
+	if _, ok := <-subscription.Events(); ok {
+		t.Fatal("expected the events channel to be closed")
+	}
+}
+
+func Test_Websocket_UsersInvalidatedRefreshesDomainUsers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fullStatisticsRequested := make(chan struct{})
+	usersRequested := make(chan struct{})
+
+	mockWebsocket := five9test.NewWebSocketHandler()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(mockWebsocket),
+		five9.SetRoundTripper(&MockRoundTripper{
+			Func: append(
+				generateLoginRequestFuncs(t),
+				func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/request_full_statistics
+					close(fullStatisticsRequested)
+
+					return &http.Response{
+						Body:       http.NoBody,
+						StatusCode: http.StatusNoContent,
+					}, nil
+				},
+				func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+					close(usersRequested)
+
+					return &http.Response{
+						Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+						StatusCode: http.StatusOK,
+					}, nil
+				},
+			),
+		}),
+	)
+
+	subscription := s.Supervisor().Subscribe(10, five9.SlowSubscriberDrop)
+	defer subscription.Unsubscribe()
+
+	go func() {
+		_ = s.Supervisor().StartWebsocket(ctx)
+	}()
+
+	select {
+	case <-fullStatisticsRequested:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for full statistics request")
+	}
+
+	if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/5006_usersInvalidated.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-usersRequested:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for domain users to be refetched")
+	}
+
+	select {
+	case event := <-subscription.Events():
+		if event != (five9.InvalidationEvent{EventID: five9types.EventIDUsersInvalidated}) {
+			t.Fatalf("expected users invalidation event, got %+v", event)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for invalidation event")
+	}
+}
+
+func Test_Websocket_IncrementalAgentStatistics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fullStatisticsRequested := make(chan struct{})
+
+	mockWebsocket := five9test.NewWebSocketHandler()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(mockWebsocket),
+		five9.SetRoundTripper(&MockRoundTripper{
+			Func: append(
+				generateLoginRequestFuncs(t),
+				func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/request_full_statistics
+					close(fullStatisticsRequested)
+
+					return &http.Response{
+						Body:       http.NoBody,
+						StatusCode: http.StatusNoContent,
+					}, nil
+				},
+				func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+					return &http.Response{
+						Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+						StatusCode: http.StatusOK,
+					}, nil
+				},
+			),
+		}),
+	)
+
+	subscription := s.Supervisor().Subscribe(10, five9.SlowSubscriberDrop)
+	defer subscription.Unsubscribe()
+
+	go func() {
+		_ = s.Supervisor().StartWebsocket(ctx)
+	}()
+
+	select {
+	case <-fullStatisticsRequested:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for full statistics request")
+	}
+
+	if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/5000_stats_agentStatistic.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/5012_incrementalStatsUpdate_agentStatistic.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for the removal, which is the last change in the incremental frame.
+	for waiting := true; waiting; {
+		select {
+		case event := <-subscription.Events():
+			if statisticsEvent, ok := event.(five9.AgentStatisticsEvent); ok && statisticsEvent.Action == five9.WebSocketEventActionRemoved {
+				if statisticsEvent.Statistics.TotalCallsCount != 12 {
+					t.Fatalf("expected last known statistics on removal, got %+v", statisticsEvent.Statistics)
+				}
+
+				waiting = false
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for agent statistics removal")
+		}
+	}
+
+	agentStatistics, err := s.Supervisor().WSAgentStatistics(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(agentStatistics) != 1 {
+		t.Fatalf("expected 1 agent in statistics cache, got %d", len(agentStatistics))
+	}
+
+	updatedStatistics, ok := agentStatistics["chris.gibson@example.com"]
+	if !ok {
+		t.Fatal("expected updated agent to remain in statistics cache")
+	}
+
+	if updatedStatistics.TotalCallsCount != 6 {
+		t.Fatalf("expected incremental update to set totalCallsCount to 6, got %d", updatedStatistics.TotalCallsCount)
+	}
+}
+
+func Test_Websocket_RecordedIncrementalFramePublishesAgentStatistics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockWebsocket := five9test.NewWebSocketHandler()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(mockWebsocket),
+		five9.SetRoundTripper(&MockRoundTripper{
+			Func: generateWSStartRequestFuncs(t),
+		}),
+	)
+
+	subscription := s.Supervisor().Subscribe(100, five9.SlowSubscriberDrop)
+	defer subscription.Unsubscribe()
+
+	go func() {
+		_ = s.Supervisor().StartWebsocket(ctx)
+	}()
+
+	if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/5012_incrementalStatsUpdate.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	updatedAgentIDs := []five9types.UserID{}
+	for len(updatedAgentIDs) < 2 {
+		select {
+		case event := <-subscription.Events():
+			if statisticsEvent, ok := event.(five9.AgentStatisticsEvent); ok {
+				updatedAgentIDs = append(updatedAgentIDs, statisticsEvent.AgentID)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for agent statistics events")
+		}
+	}
+
+	if updatedAgentIDs[0] != "4635040" {
+		t.Fatalf("expected first agent statistics update for 4635040, got %s", updatedAgentIDs[0])
+	}
 }
 
-func (h *MockWebsocketHandler) Connect(ctx context.Context, connectionURL string, httpClient *http.Client) error {
-	if h.ConnectionError != nil {
-		return h.ConnectionError
+func Test_Websocket_CampaignCaches(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fullStatisticsRequested := make(chan struct{})
+
+	mockWebsocket := five9test.NewWebSocketHandler()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(mockWebsocket),
+		five9.SetRoundTripper(&MockRoundTripper{
+			Func: append(
+				generateLoginRequestFuncs(t),
+				func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/request_full_statistics
+					close(fullStatisticsRequested)
+
+					return &http.Response{
+						Body:       http.NoBody,
+						StatusCode: http.StatusNoContent,
+					}, nil
+				},
+				func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/campaigns
+					return &http.Response{
+						Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllCampaigns_200.json"),
+						StatusCode: http.StatusOK,
+					}, nil
+				},
+			),
+		}),
+	)
+
+	subscription := s.Supervisor().Subscribe(20, five9.SlowSubscriberDrop)
+	defer subscription.Unsubscribe()
+
+	go func() {
+		_ = s.Supervisor().StartWebsocket(ctx)
+	}()
+
+	select {
+	case <-fullStatisticsRequested:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for full statistics request")
+	}
+
+	if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/5000_stats_campaigns.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/5012_incrementalStatsUpdate_campaigns.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for the outbound campaign manager update, which is the last change in the incremental frame.
+	for waiting := true; waiting; {
+		select {
+		case event := <-subscription.Events():
+			if _, ok := event.(five9.OutboundCampaignManagerEvent); ok {
+				waiting = false
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for outbound campaign manager update")
+		}
+	}
+
+	inboundStatistics, err := s.Supervisor().WSInboundCampaignStatistics(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if inboundStatistics["Inbound Support"].AbandonCallRate != 0.25 {
+		t.Fatalf("expected abandon rate of 0.25, got %f", inboundStatistics["Inbound Support"].AbandonCallRate)
+	}
+
+	outboundStatistics, err := s.Supervisor().WSOutboundCampaignStatistics(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if outboundStatistics["Outbound Renewals"].AbandonCallRate != 0.04 {
+		t.Fatalf("expected abandon rate of 0.04, got %f", outboundStatistics["Outbound Renewals"].AbandonCallRate)
+	}
+
+	outboundManager, err := s.Supervisor().WSOutboundCampaignManager(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if outboundManager["Outbound Renewals"].CallsToAgentRatio != 1.8 {
+		t.Fatalf("expected dialer ratio of 1.8, got %f", outboundManager["Outbound Renewals"].CallsToAgentRatio)
 	}
 
-	return nil
+	campaignState, err := s.Supervisor().WSCampaignState(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(campaignState) != 2 {
+		t.Fatalf("expected 2 campaigns in state cache, got %d", len(campaignState))
+	}
+
+	if campaignState["Outbound Renewals"].CampaignState != five9types.CampaignStateLabelNotRunning {
+		t.Fatalf("expected outbound campaign to be stopped, got %s", campaignState["Outbound Renewals"].CampaignState)
+	}
 }
 
-func (h *MockWebsocketHandler) Read(ctx context.Context) ([]byte, error) {
-	newMessage := <-h.clientQueue
-	h.checkFrameContent(newMessage)
+func Test_Websocket_UserSessions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fullStatisticsRequested := make(chan struct{})
+
+	mockWebsocket := five9test.NewWebSocketHandler()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(mockWebsocket),
+		five9.SetRoundTripper(&MockRoundTripper{
+			Func: append(
+				generateLoginRequestFuncs(t),
+				func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/request_full_statistics
+					close(fullStatisticsRequested)
+
+					return &http.Response{
+						Body:       http.NoBody,
+						StatusCode: http.StatusNoContent,
+					}, nil
+				},
+				func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+					return &http.Response{
+						Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+						StatusCode: http.StatusOK,
+					}, nil
+				},
+			),
+		}),
+	)
+
+	subscription := s.Supervisor().Subscribe(10, five9.SlowSubscriberDrop)
+	defer subscription.Unsubscribe()
+
+	go func() {
+		_ = s.Supervisor().StartWebsocket(ctx)
+	}()
+
+	select {
+	case <-fullStatisticsRequested:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for full statistics request")
+	}
+
+	if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/5000_stats_userSessions.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for the user session snapshot, which is the last data source in the frame.
+	for waiting := true; waiting; {
+		select {
+		case event := <-subscription.Events():
+			if event == (five9.StatisticsSnapshotEvent{DataSource: five9types.DataSourceUserSession}) {
+				waiting = false
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for user session snapshot")
+		}
+	}
+
+	userSessions, err := s.Supervisor().WSUserSessions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(userSessions) != 3 {
+		t.Fatalf("expected 3 user sessions, got %d", len(userSessions))
+	}
+
+	agentSession := userSessions["c410ee276f9c205fbc2375e6dffde24f"]
+	if agentSession.User.UserName != "aaron.ellington@example.com" {
+		t.Fatalf("expected agent session to be joined with domain user, got %+v", agentSession.User)
+	}
+
+	if agentSession.Station == nil || agentSession.Station.StationType != "PSTN" {
+		t.Fatalf("expected agent session to be joined with station, got %+v", agentSession.Station)
+	}
 
-	return newMessage, nil
+	supervisorSession := userSessions["b309dd165e8b194eab1264d5ceecd13e"]
+	if supervisorSession.Session.Role != five9types.UserRoleDomainSupervisor || supervisorSession.Station != nil {
+		t.Fatalf("expected supervisor session without station, got %+v", supervisorSession)
+	}
+
+	if userSessions["d521ff387a0d316acd3486f7e00ef35a"].User.UserName != "new.hire@example.com" {
+		t.Fatal("expected session of unknown user to fall back to the session user name")
+	}
 }
 
-func (h *MockWebsocketHandler) Write(ctx context.Context, data []byte) error {
-	newMessage := string(data)
+func Test_Websocket_DuplicateConnection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockWebsocket := five9test.NewWebSocketHandler()
+
+	loginFuncs := generateWSStartRequestFuncs(t)
+	sendLoginResponse := loginFuncs[0]
+	loginFuncs[0] = func(r *http.Request) (*http.Response, error) { // https://app.five9.com/supsvcs/rs/svc/auth/login
+		// This runs on the websocket goroutine, so report failures without stopping the test goroutine.
+		payload := five9types.LoginPayload{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
 
-	switch newMessage {
-	case "ping":
-		fileBytes, err := os.ReadFile("test/webSocketFrames/1202_pong.json")
-		if err != nil {
-			return err
+		if payload.Policy != five9types.PolicyAttachExisting {
+			t.Errorf("expected login policy %s, got %s", five9types.PolicyAttachExisting, payload.Policy)
 		}
 
-		h.WriteToClient(ctx, fileBytes)
+		return sendLoginResponse(r)
+	}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(mockWebsocket),
+		five9.SetRoundTripper(&MockRoundTripper{
+			Func: loginFuncs,
+		}),
+		five9.SetLoginPolicy(five9types.PolicyAttachExisting),
+	)
+
+	websocketErr := make(chan error, 1)
+	go func() {
+		websocketErr <- s.Supervisor().RunWebsocket(ctx, five9.WebsocketRunnerConfig{})
+	}()
+
+	if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/1020_duplicateConnection.json")); err != nil {
+		t.Fatal(err)
+	}
 
-		return nil
+	select {
+	case err := <-websocketErr:
+		if !errors.Is(err, five9.ErrWebSocketDuplicateConnection) {
+			t.Fatalf("expected ErrWebSocketDuplicateConnection, got %v", err)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for the websocket to yield to the duplicate connection")
+	}
+}
+
+func Test_Websocket_SequenceGapTriggersResync(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fullStatisticsRequests := make(chan struct{}, 3)
+	usersRequests := make(chan struct{}, 1)
+	respond := func(r *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(r.URL.Path, "/users") { // supsvcs/rs/svc/orgs/:organizationID/users
+			usersRequests <- struct{}{}
+
+			return &http.Response{
+				Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+				StatusCode: http.StatusOK,
+			}, nil
+		}
+
+		fullStatisticsRequests <- struct{}{} // supsvcs/rs/svc/supervisors/:userID/request_full_statistics
+
+		return &http.Response{
+			Body:       http.NoBody,
+			StatusCode: http.StatusNoContent,
+		}, nil
+	}
+
+	mockWebsocket := five9test.NewWebSocketHandler()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(mockWebsocket),
+		five9.SetRoundTripper(&MockRoundTripper{
+			Func: append(
+				generateLoginRequestFuncs(t),
+				respond,
+				respond,
+				respond,
+			),
+		}),
+	)
+
+	go func() {
+		_ = s.Supervisor().StartWebsocket(ctx)
+	}()
+
+	select {
+	case <-fullStatisticsRequests:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for full statistics request")
+	}
+
+	// Message 210977, then the invalidation 210978, which shares the counter of the incremental updates.
+	for _, frame := range []string{"5012_incrementalStatsUpdate_removed.json", "5006_usersInvalidated.json"} {
+		if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/"+frame)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case <-usersRequests:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for domain users to be refetched")
+	}
+
+	if s.Supervisor().WSResyncCount() != 0 {
+		t.Fatalf("expected no resync for frames in sequence, got %d", s.Supervisor().WSResyncCount())
+	}
+
+	// Skips message 210979
+	if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/5012_incrementalStatsUpdate_gap.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-fullStatisticsRequests:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for full statistics resync")
+	}
+
+	if s.Supervisor().WSResyncCount() != 1 {
+		t.Fatalf("expected 1 resync, got %d", s.Supervisor().WSResyncCount())
+	}
+
+	// Subscribe now, so only the events of the frames below are waited for.
+	subscription := s.Supervisor().Subscribe(100, five9.SlowSubscriberDrop)
+	defer subscription.Unsubscribe()
+
+	// The snapshot sets a new baseline, so the broadcast that follows is in sequence whatever its message ID.
+	for _, frame := range []string{"5000_stats_agentStatistic.json", "5012_incrementalStatsUpdate_agentStatistic.json"} {
+		if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/"+frame)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	waitForAgentStatisticsEvent(t, subscription)
+
+	if s.Supervisor().WSResyncCount() != 1 {
+		t.Fatalf("expected the snapshot to set a new baseline, got %d resyncs", s.Supervisor().WSResyncCount())
+	}
+}
+
+func Test_Websocket_RecordedSnapshotAndBroadcastInSequence(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockWebsocket := five9test.NewWebSocketHandler()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(mockWebsocket),
+		five9.SetRoundTripper(&MockRoundTripper{
+			Func: generateWSStartRequestFuncs(t),
+		}),
+	)
+
+	subscription := s.Supervisor().Subscribe(100, five9.SlowSubscriberDrop)
+	defer subscription.Unsubscribe()
+
+	go func() {
+		_ = s.Supervisor().StartWebsocket(ctx)
+	}()
+
+	// The snapshot is message 15641 for this user, the update that follows it is broadcast message 210977.
+	for _, frame := range []string{"5000_stats.json", "5012_incrementalStatsUpdate.json"} {
+		if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/"+frame)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	waitForAgentStatisticsEvent(t, subscription)
+
+	if s.Supervisor().WSResyncCount() != 0 {
+		t.Fatalf("expected no resync for the recorded frames, got %d", s.Supervisor().WSResyncCount())
+	}
+}
+
+// waitForAgentStatisticsEvent waits until an incremental update has been processed.
+func waitForAgentStatisticsEvent(t *testing.T, subscription *five9.WebSocketSubscription) {
+	t.Helper()
+
+	for {
+		select {
+		case event := <-subscription.Events():
+			if _, ok := event.(five9.AgentStatisticsEvent); ok {
+				return
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for agent statistics events")
+		}
+	}
+}
+
+func Test_Websocket_FailedResyncKeepsConnection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fullStatisticsRequests := make(chan int, 2)
+	requestFullStatistics := func(statusCode int) func(r *http.Request) (*http.Response, error) {
+		return func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/request_full_statistics
+			fullStatisticsRequests <- statusCode
+
+			return &http.Response{
+				Body:       http.NoBody,
+				StatusCode: statusCode,
+			}, nil
+		}
+	}
+
+	mockWebsocket := five9test.NewWebSocketHandler()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(mockWebsocket),
+		five9.SetRetryPolicy(five9.RetryPolicy{MaxAttempts: 1}),
+		five9.SetRoundTripper(&MockRoundTripper{
+			Func: append(
+				generateLoginRequestFuncs(t),
+				requestFullStatistics(http.StatusNoContent),
+				requestFullStatistics(http.StatusBadRequest),
+			),
+		}),
+	)
+
+	websocketErr := make(chan error, 1)
+	go func() {
+		websocketErr <- s.Supervisor().StartWebsocket(ctx)
+	}()
+
+	<-fullStatisticsRequests
+
+	for _, frame := range []string{"5012_incrementalStatsUpdate_removed.json", "5012_incrementalStatsUpdate_gap.json"} {
+		if err := mockWebsocket.SendFrame(ctx, createByteSliceFromFile(t, "test/webSocketFrames/"+frame)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case <-fullStatisticsRequests:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for full statistics resync")
+	}
+
+	// The connection still reads frames after the resync failed.
+	sendCtx, cancelSend := context.WithTimeout(ctx, time.Second*5)
+	defer cancelSend()
+
+	if err := mockWebsocket.SendFrame(sendCtx, createByteSliceFromFile(t, "test/webSocketFrames/1202_pong.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-websocketErr:
+		t.Fatalf("expected the connection to stay up, got %v", err)
 	default:
-		return errors.New("unsupported message")
-	}
-}
-
-// Mock the data writes to the WS Server.
-func (h *MockWebsocketHandler) WriteToClient(_ context.Context, data []byte) {
-	h.clientQueue <- data
-}
-
-func (h *MockWebsocketHandler) Close() {}
-
-// func Test_WebSocketPingResponse_Success(t *testing.T) {
-// 	ctx := context.Background()
-// 	testErr := make(chan error)
-
-// 	mockWebsocket := &MockWebsocketHandler{
-// 		clientQueue: make(chan []byte),
-// 		serverQueue: make(chan []byte),
-// 		checkFrameContent: func(data []byte) {
-// 			targetFrame := five9types.WebsocketMessage{}
-// 			if err := json.Unmarshal(data, &targetFrame); err != nil {
-// 				testErr <- err
-// 			}
-
-// 			if targetFrame.Context.EventID == five9types.EventIDPongReceived {
-// 				testErr <- nil
-// 			}
-// 		},
-// 	}
-
-// 	mockRoundTripper := MockRoundTripper{
-// 		Func: generateWSLoginRequestFuncs(t),
-// 	}
-
-// 	s := five9.NewService(
-// 		five9types.PasswordCredentials{},
-// 		five9.SetWebsocketHandler(mockWebsocket),
-// 		five9.SetRoundTripper(&mockRoundTripper),
-// 	)
-
-// 	go func() {
-// 		if err := s.Supervisor().StartWebsocket(ctx); err != nil {
-// 			testErr <- err
-
-// 			return
-// 		}
-// 	}()
-
-// 	if err := <-testErr; err != nil {
-// 		t.Fatal(err)
-// 	}
-// }
-
-// func Test_GetInternalCache_Success(t *testing.T) {
-// 	ctx := context.Background()
-// 	testErr := make(chan error)
-
-// 	mockWebsocket := &MockWebsocketHandler{
-// 		clientQueue:       make(chan []byte),
-// 		serverQueue:       make(chan []byte),
-// 		checkFrameContent: func(data []byte) {},
-// 	}
-
-// 	mockRoundTripper := MockRoundTripper{
-// 		Func: generateWSLoginRequestFuncs(t),
-// 	}
-
-// 	s := five9.NewService(
-// 		five9types.PasswordCredentials{},
-// 		five9.SetWebsocketHandler(mockWebsocket),
-// 		five9.SetRoundTripper(&mockRoundTripper),
-// 		five9.AddRequestPreprocessor(func(r *http.Request) error {
-// 			t.Logf("API Call Made: [%s] %s\n", r.Method, r.URL.String())
-
-// 			return nil
-// 		}),
-// 	)
-
-// 	go func() {
-// 		if err := s.Supervisor().StartWebsocket(ctx); err != nil {
-// 			testErr <- err
-
-// 			return
-// 		}
-// 	}()
-
-// 	mockWebsocket.WriteToClient(ctx, createByteSliceFromFile(t, "test/webSocketFrames/1010_successfulWebSocketConnection.json"))
-// 	mockWebsocket.WriteToClient(ctx, createByteSliceFromFile(t, "test/webSocketFrames/5000_stats.json"))
-// 	time.Sleep(time.Second)
-
-// 	agents, err := s.Supervisor().WSAgentState(ctx)
-// 	if err != nil {
-// 		t.Fatal(err)
-// 	}
-
-// 	if len(agents) != 2 {
-// 		t.Fatalf("expected 2 agents in internal cache, got %d", len(agents))
-// 	}
-
-// 	if err := <-testErr; err != nil {
-// 		t.Fatal(err)
-// 	}
-// }
+	}
+}
+
+func Test_RunWebsocket_GivesUpAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	connectedCount := 0
+	disconnectedCount := 0
+
+	// An empty queue fails every login attempt
+	mockRoundTripper := MockRoundTripper{}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(five9test.NewWebSocketHandler()),
+		five9.SetRoundTripper(&mockRoundTripper),
+	)
+
+	err := s.Supervisor().RunWebsocket(ctx, five9.WebsocketRunnerConfig{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond * 5,
+		MaxAttempts:    3,
+		OnConnected: func() {
+			connectedCount++
+		},
+		OnDisconnected: func(err error) {
+			disconnectedCount++
+		},
+	})
+	if !errors.Is(err, five9.ErrWebSocketMaxAttemptsReached) {
+		t.Fatalf("expected ErrWebSocketMaxAttemptsReached, got %v", err)
+	}
+
+	if connectedCount != 0 {
+		t.Fatalf("expected no successful connections, got %d", connectedCount)
+	}
+
+	if disconnectedCount != 3 {
+		t.Fatalf("expected 3 disconnects, got %d", disconnectedCount)
+	}
+}
+
+func Test_RunWebsocket_StopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(five9test.NewWebSocketHandler()),
+		five9.SetRoundTripper(&MockRoundTripper{}),
+	)
+
+	err := s.Supervisor().RunWebsocket(ctx, five9.WebsocketRunnerConfig{
+		InitialBackoff: time.Hour,
+		OnDisconnected: func(err error) {
+			cancel()
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func Test_RunWebsocket_RestartDuringRequestsKeepsLogin(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	server.SetUsers(five9types.AgentInfo{ID: five9test.DefaultUserID, UserName: "supervisor@example.com"})
+
+	s := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+
+	resynced := make(chan struct{}, 1)
+	runnerErr := make(chan error, 1)
+
+	go func() {
+		runnerErr <- s.Supervisor().RunWebsocket(ctx, five9.WebsocketRunnerConfig{
+			InitialBackoff: time.Millisecond * 10,
+			OnResynced: func() {
+				select {
+				case resynced <- struct{}{}:
+				default:
+				}
+			},
+		})
+	}()
+
+	waitForResync := func() {
+		t.Helper()
+
+		select {
+		case <-resynced:
+		case err := <-runnerErr:
+			t.Fatal(err)
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for the WebSocket")
+		}
+	}
+
+	waitForResync()
+
+	if _, err := s.Supervisor().GetOwnUserInfo(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	stop := make(chan struct{})
+	wg := &sync.WaitGroup{}
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				if _, err := s.Supervisor().GetOwnUserInfo(ctx); err != nil {
+					t.Error(err)
+
+					return
+				}
+
+				if _, err := s.Supervisor().Token(ctx); err != nil {
+					t.Error(err)
+
+					return
+				}
+
+				// The WebSocket cache is reset on every restart, so only the access matters, not the result.
+				_, _ = s.Supervisor().WSAgentState(ctx)
+			}
+		}()
+	}
+
+	for i := 0; i < 3; i++ {
+		server.DisconnectWebSockets()
+		waitForResync()
+	}
+
+	close(stop)
+	wg.Wait()
+
+	if logins := countRequests(server, "POST /supsvcs/rs/svc/auth/login"); logins != 1 {
+		t.Fatalf("expected the WebSocket restarts to keep the login, got %d logins", logins)
+	}
+
+	if fetches := countRequests(server, "GET /supsvcs/rs/svc/orgs/987654321/users"); fetches != 1 {
+		t.Fatalf("expected the WebSocket restarts to keep the domain users cached, got %d fetches", fetches)
+	}
+}
+
+func Test_Websocket_StaleInvalidationRefreshIsDropped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	server.SetUsers(five9types.AgentInfo{ID: "1", UserName: "agent@example.com"})
+
+	blockedRefresh := make(chan struct{})
+	releaseRefresh := make(chan struct{})
+	blockNextRefresh := &atomic.Bool{}
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(
+			server.ConfigFuncs(),
+			five9.AddRequestMiddleware(func(next five9.RequestHandler) five9.RequestHandler {
+				return func(request *http.Request) (*http.Response, error) {
+					response, err := next(request)
+
+					// Hold back the response of the first refetch until a newer refetch has finished.
+					if strings.HasSuffix(request.URL.Path, "/users") && blockNextRefresh.CompareAndSwap(true, false) {
+						close(blockedRefresh)
+						<-releaseRefresh
+					}
+
+					return response, err
+				}
+			}),
+		)...,
+	)
+
+	resynced := make(chan struct{}, 1)
+	go func() {
+		_ = s.Supervisor().RunWebsocket(ctx, five9.WebsocketRunnerConfig{
+			OnResynced: func() {
+				resynced <- struct{}{}
+			},
+		})
+	}()
+
+	select {
+	case <-resynced:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for the WebSocket")
+	}
+
+	blockNextRefresh.Store(true)
+
+	if err := server.SendEvent(ctx, five9types.EventIDUsersInvalidated, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-blockedRefresh:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for the first refetch")
+	}
+
+	// The supervisor is only in the newer list of users.
+	server.SetUsers(five9types.AgentInfo{ID: five9test.DefaultUserID, UserName: "supervisor@example.com"})
+
+	if err := server.SendEvent(ctx, five9types.EventIDUsersInvalidated, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Second * 5)
+	for {
+		if _, err := s.Supervisor().GetOwnUserInfo(ctx); err == nil {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the second refetch")
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	// Let any other refetch of the newer users finish, then let the stale refetch finish.
+	time.Sleep(time.Millisecond * 50)
+	close(releaseRefresh)
+	time.Sleep(time.Millisecond * 100)
+
+	if _, err := s.Supervisor().GetOwnUserInfo(ctx); err != nil {
+		t.Fatalf("expected the stale refetch to be dropped, got %v", err)
+	}
+}
+
+func Test_StartWebsocket_RejectedHandshakeLogsInAgain(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	s := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Logging in elsewhere ends the session, so the handshake is rejected.
+	other := five9.NewService(five9types.PasswordCredentials{}, server.ConfigFuncs()...)
+	if _, err := other.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Supervisor().StartWebsocket(ctx); !errors.Is(err, five9.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if logins := countRequests(server, "POST /supsvcs/rs/svc/auth/login"); logins != 3 {
+		t.Fatalf("expected to log in again after the handshake was rejected, got %d logins", logins)
+	}
+}
+
+func Test_StartWebsocket_ConnectErrorKeepsLogin(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	mockWebsocket := five9test.NewWebSocketHandler()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(server.ConfigFuncs(), five9.SetWebsocketHandler(mockWebsocket))...,
+	)
+
+	connectErrors := []error{
+		errors.New("tls: handshake failure"),
+		&five9.Error{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"},
+	}
+
+	for _, connectErr := range connectErrors {
+		mockWebsocket.SetConnectError(connectErr)
+
+		if err := s.Supervisor().StartWebsocket(ctx); !errors.Is(err, connectErr) {
+			t.Fatalf("expected %v, got %v", connectErr, err)
+		}
+	}
+
+	if logins := countRequests(server, "POST /supsvcs/rs/svc/auth/login"); logins != 1 {
+		t.Fatalf("expected to keep the login when the session was not rejected, got %d logins", logins)
+	}
+}
+
+func Test_RunWebsocket_DisableJitter(t *testing.T) {
+	ctx := context.Background()
+
+	backoff := time.Millisecond * 20
+	disconnects := []time.Time{}
+
+	// An empty queue fails every login attempt
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetWebsocketHandler(five9test.NewWebSocketHandler()),
+		five9.SetRoundTripper(&MockRoundTripper{}),
+	)
+
+	err := s.Supervisor().RunWebsocket(ctx, five9.WebsocketRunnerConfig{
+		InitialBackoff: backoff,
+		Multiplier:     1,
+		DisableJitter:  true,
+		MaxAttempts:    6,
+		OnDisconnected: func(err error) {
+			disconnects = append(disconnects, time.Now())
+		},
+	})
+	if !errors.Is(err, five9.ErrWebSocketMaxAttemptsReached) {
+		t.Fatalf("expected ErrWebSocketMaxAttemptsReached, got %v", err)
+	}
+
+	// With jitter, some of the delays would be shorter than the backoff.
+	for i := 1; i < len(disconnects); i++ {
+		if delay := disconnects[i].Sub(disconnects[i-1]); delay < backoff {
+			t.Fatalf("expected every reconnect to wait %s, waited %s", backoff, delay)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package five9
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9/five9types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// instrumentationName identifies the spans and metrics created by this package.
+const instrumentationName = "github.com/equalsgibson/five9-go/five9"
+
+type telemetry struct {
+	tracer            trace.Tracer
+	meter             metric.Meter
+	apiDuration       metric.Float64Histogram
+	frames            metric.Int64Counter
+	frameDuration     metric.Float64Histogram
+	dataSourceUpdates metric.Int64Counter
+	cacheAge          metric.Registration
+}
+
+// newTelemetry creates the instruments of the package. Nil providers are replaced with no-op ones.
+func newTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) *telemetry {
+	if tracerProvider == nil {
+		tracerProvider = tracenoop.NewTracerProvider()
+	}
+
+	if meterProvider == nil {
+		meterProvider = metricnoop.NewMeterProvider()
+	}
+
+	t := &telemetry{
+		tracer: tracerProvider.Tracer(instrumentationName),
+		meter:  meterProvider.Meter(instrumentationName),
+	}
+
+	var err error
+
+	t.apiDuration, err = t.meter.Float64Histogram("five9.api.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of REST calls to Five9, including retries."),
+	)
+	handleTelemetryError(err)
+
+	t.frames, err = t.meter.Int64Counter("five9.websocket.frames",
+		metric.WithUnit("{frame}"),
+		metric.WithDescription("WebSocket frames received from Five9, by event ID."),
+	)
+	handleTelemetryError(err)
+
+	t.frameDuration, err = t.meter.Float64Histogram("five9.websocket.frame.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Time taken to process a WebSocket frame."),
+	)
+	handleTelemetryError(err)
+
+	t.dataSourceUpdates, err = t.meter.Int64Counter("five9.websocket.updates",
+		metric.WithUnit("{update}"),
+		metric.WithDescription("Statistics updates received over the WebSocket, by data source."),
+	)
+	handleTelemetryError(err)
+
+	return t
+}
+
+// startAPICall starts the span of a REST call. The returned function ends the span and records the duration of
+// the call, so it must be called once all attempts are done.
+func (t *telemetry) startAPICall(request *http.Request, apiContext string) (context.Context, func(error)) {
+	start := time.Now()
+
+	attributes := []attribute.KeyValue{
+		attribute.String("http.request.method", request.Method),
+		attribute.String("five9.api_context", apiContext),
+	}
+
+	ctx, span := t.tracer.Start(request.Context(), fmt.Sprintf("five9 %s %s", request.Method, request.URL.Path),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+		trace.WithAttributes(attribute.String("url.path", request.URL.Path)),
+	)
+
+	return ctx, func(err error) {
+		if err != nil {
+			five9Error := &Error{}
+			if errors.As(err, &five9Error) {
+				attributes = append(attributes, attribute.Int("http.response.status_code", five9Error.StatusCode))
+			}
+
+			attributes = append(attributes, attribute.String("error.type", fmt.Sprintf("%T", err)))
+
+			span.SetAttributes(attributes...)
+		}
+
+		endSpan(span, err)
+
+		t.apiDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attributes...))
+	}
+}
+
+// startFrame starts the span of a WebSocket frame. The returned function ends the span and records the frame.
+func (t *telemetry) startFrame(ctx context.Context, eventID five9types.EventID) (context.Context, func(error)) {
+	start := time.Now()
+
+	attributes := metric.WithAttributes(attribute.String("five9.event_id", string(eventID)))
+
+	ctx, span := t.tracer.Start(ctx, "five9 webSocket frame",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attribute.String("five9.event_id", string(eventID))),
+	)
+
+	return ctx, func(err error) {
+		endSpan(span, err)
+
+		t.frames.Add(ctx, 1, attributes)
+		t.frameDuration.Record(ctx, time.Since(start).Seconds(), attributes)
+	}
+}
+
+// dataSourceUpdated counts a statistics update for dataSource.
+func (t *telemetry) dataSourceUpdated(ctx context.Context, dataSource five9types.DataSource) {
+	t.dataSourceUpdates.Add(ctx, 1, metric.WithAttributes(attribute.String("five9.data_source", string(dataSource))))
+}
+
+// observeCacheAge reports the age of the supervisor caches in the five9.cache.age gauge.
+func (t *telemetry) observeCacheAge(s *SupervisorService) {
+	caches := map[string]interface{ GetCacheAge() *time.Duration }{
+		string(five9types.DataSourceAgentState):                 s.webSocketCache.agentState,
+		string(five9types.DataSourceAgentStatistic):             s.webSocketCache.agentStatistics,
+		string(five9types.DataSourceACDStatus):                  s.webSocketCache.acdState,
+		string(five9types.DataSourceCampaignState):              s.webSocketCache.campaignState,
+		string(five9types.DataSourceInboundCampaignStatistics):  s.webSocketCache.inboundCampaignStatistics,
+		string(five9types.DataSourceOutboundCampaignStatistics): s.webSocketCache.outboundCampaignStatistics,
+		string(five9types.DataSourceOutboundCampaignManager):    s.webSocketCache.outboundCampaignManager,
+		string(five9types.DataSourceUserSession):                s.webSocketCache.userSessions,
+		string(five9types.DataSourceStations):                   s.webSocketCache.stations,
+		"DOMAIN_USERS":                                          s.domainMetadataCache.agentInfoState,
+		"QUEUES":                                                s.domainMetadataCache.queueInfoState,
+		"REASON_CODES":                                          s.domainMetadataCache.reasonCodeInfoState,
+		"CAMPAIGNS":                                             s.domainMetadataCache.campaignInfoState,
+	}
+
+	gauge, err := t.meter.Float64ObservableGauge("five9.cache.age",
+		metric.WithUnit("s"),
+		metric.WithDescription("Time since a cache was last updated. Caches that have never been filled are not reported."),
+	)
+	if err != nil {
+		handleTelemetryError(err)
+
+		return
+	}
+
+	t.cacheAge, err = t.meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		for name, cache := range caches {
+			if age := cache.GetCacheAge(); age != nil {
+				observer.ObserveFloat64(gauge, age.Seconds(), metric.WithAttributes(attribute.String("five9.cache", name)))
+			}
+		}
+
+		return nil
+	}, gauge)
+	handleTelemetryError(err)
+}
+
+// stopObservingCacheAge unregisters the callback of observeCacheAge, if there is one.
+func (t *telemetry) stopObservingCacheAge() {
+	if t.cacheAge == nil {
+		return
+	}
+
+	handleTelemetryError(t.cacheAge.Unregister())
+	t.cacheAge = nil
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// handleTelemetryError passes err to the OpenTelemetry error handler. The default handler logs even nil errors.
+func handleTelemetryError(err error) {
+	if err != nil {
+		otel.Handle(err)
+	}
+}
@@ -0,0 +1,264 @@
+package five9_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/equalsgibson/five9-go/five9"
+	"github.com/equalsgibson/five9-go/five9/five9test"
+	"github.com/equalsgibson/five9-go/five9/five9types"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_SetTelemetry_APICallSpans(t *testing.T) {
+	ctx := context.Background()
+
+	mockRoundTripper := MockRoundTripper{
+		Func: append(
+			generateLoginRequestFuncs(t),
+			func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
+				return &http.Response{
+					Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
+					StatusCode: http.StatusOK,
+				}, nil
+			},
+		),
+	}
+
+	spanExporter := tracetest.NewInMemoryExporter()
+	metricReader := sdkmetric.NewManualReader()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetRoundTripper(&mockRoundTripper),
+		five9.SetTelemetry(
+			sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter)),
+			sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader)),
+		),
+	)
+
+	if _, err := s.Supervisor().GetAllDomainUsers(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range spanExporter.GetSpans() {
+		spans[span.Name] = span
+	}
+
+	usersSpan, ok := spans["five9 GET /supsvcs/rs/svc/orgs/:organizationID/users"]
+	if !ok {
+		t.Fatalf("expected a span for the API call, got %v", spanNames(spans))
+	}
+
+	loginSpan, ok := spans["five9 login"]
+	if !ok || loginSpan.Parent.SpanID() != usersSpan.SpanContext.SpanID() {
+		t.Fatalf("expected the login to be a child of the API call, got %v", spanNames(spans))
+	}
+
+	for _, name := range []string{
+		"five9 POST /supsvcs/rs/svc/auth/login",
+		"five9 GET /supsvcs/rs/svc/auth/metadata",
+		"five9 GET /supsvcs/rs/svc/supervisors/:userID/login_state",
+		"five9 PUT /supsvcs/rs/svc/supervisors/:userID/session_start",
+	} {
+		if spans[name].Parent.SpanID() != loginSpan.SpanContext.SpanID() {
+			t.Errorf("expected %q to be a child of the login", name)
+		}
+	}
+
+	apiDuration := collectMetric(t, metricReader, "five9.api.duration")
+
+	histogram, ok := apiDuration.Data.(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("expected a histogram, got %T", apiDuration.Data)
+	}
+
+	calls := uint64(0)
+	for _, dataPoint := range histogram.DataPoints {
+		calls += dataPoint.Count
+	}
+
+	// One for each of the 5 login calls, and one for the users.
+	if calls != 6 {
+		t.Fatalf("expected 6 API calls to be recorded, got %d", calls)
+	}
+}
+
+func Test_SetTelemetry_WebSocketMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	server.SetStatistics(five9test.StatisticsSnapshot{
+		DataSource: five9types.DataSourceAgentState,
+		Data: []five9types.AgentState{
+			{ID: "1001", State: five9types.UserStateReady},
+		},
+	})
+
+	metricReader := sdkmetric.NewManualReader()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(
+			server.ConfigFuncs(),
+			five9.SetTelemetry(nil, sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))),
+		)...,
+	)
+
+	subscription := s.Supervisor().Subscribe(10, five9.SlowSubscriberDrop)
+	defer subscription.Unsubscribe()
+
+	go func() {
+		_ = s.Supervisor().StartWebsocket(ctx)
+	}()
+
+	for received := false; !received; {
+		select {
+		case event := <-subscription.Events():
+			_, received = event.(five9.StatisticsSnapshotEvent)
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out waiting for the statistics snapshot")
+		}
+	}
+
+	assertSumDataPoint(t, collectMetric(t, metricReader, "five9.websocket.frames"),
+		attribute.String("five9.event_id", string(five9types.EventIDSupervisorStats)),
+	)
+
+	assertSumDataPoint(t, collectMetric(t, metricReader, "five9.websocket.updates"),
+		attribute.String("five9.data_source", string(five9types.DataSourceAgentState)),
+	)
+
+	cacheAge, ok := collectMetric(t, metricReader, "five9.cache.age").Data.(metricdata.Gauge[float64])
+	if !ok {
+		t.Fatal("expected a gauge for the cache age")
+	}
+
+	for _, dataPoint := range cacheAge.DataPoints {
+		if cache, _ := dataPoint.Attributes.Value("five9.cache"); cache.AsString() == string(five9types.DataSourceAgentState) {
+			return
+		}
+	}
+
+	t.Fatalf("expected the age of the agent state cache, got %+v", cacheAge.DataPoints)
+}
+
+func Test_SetTelemetry_ReplacedMeterStopsObservingCacheAge(t *testing.T) {
+	ctx := context.Background()
+
+	server := five9test.NewServer()
+	defer server.Close()
+
+	replacedReader := sdkmetric.NewManualReader()
+	metricReader := sdkmetric.NewManualReader()
+
+	s := five9.NewService(
+		five9types.PasswordCredentials{},
+		append(
+			server.ConfigFuncs(),
+			five9.SetTelemetry(nil, sdkmetric.NewMeterProvider(sdkmetric.WithReader(replacedReader))),
+			five9.SetTelemetry(nil, sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader))),
+		)...,
+	)
+
+	if _, err := s.Supervisor().GetReasonCodeInfoMap(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	resourceMetrics := metricdata.ResourceMetrics{}
+	if err := replacedReader.Collect(ctx, &resourceMetrics); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		for _, metric := range scopeMetrics.Metrics {
+			if gauge, ok := metric.Data.(metricdata.Gauge[float64]); ok && len(gauge.DataPoints) > 0 {
+				t.Fatalf("expected the replaced meter to stop observing %s, got %+v", metric.Name, gauge.DataPoints)
+			}
+		}
+	}
+
+	if _, ok := collectMetric(t, metricReader, "five9.cache.age").Data.(metricdata.Gauge[float64]); !ok {
+		t.Fatal("expected a gauge for the cache age")
+	}
+}
+
+func Test_NewService_TelemetrySilentByDefault(t *testing.T) {
+	buffer := &bytes.Buffer{}
+
+	output := log.Writer()
+	defer log.SetOutput(output)
+
+	log.SetOutput(buffer)
+
+	five9.NewService(five9types.PasswordCredentials{})
+
+	five9.NewService(
+		five9types.PasswordCredentials{},
+		five9.SetTelemetry(nil, sdkmetric.NewMeterProvider()),
+		five9.SetTelemetry(nil, sdkmetric.NewMeterProvider()),
+	)
+
+	if buffer.Len() != 0 {
+		t.Fatalf("expected nothing to be logged, got %q", buffer.String())
+	}
+}
+
+func collectMetric(t *testing.T, reader sdkmetric.Reader, name string) metricdata.Metrics {
+	t.Helper()
+
+	resourceMetrics := metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), &resourceMetrics); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		for _, metric := range scopeMetrics.Metrics {
+			if metric.Name == name {
+				return metric
+			}
+		}
+	}
+
+	t.Fatalf("metric %s was not recorded", name)
+
+	return metricdata.Metrics{}
+}
+
+func assertSumDataPoint(t *testing.T, metric metricdata.Metrics, expected attribute.KeyValue) {
+	t.Helper()
+
+	sum, ok := metric.Data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("expected %s to be a sum, got %T", metric.Name, metric.Data)
+	}
+
+	for _, dataPoint := range sum.DataPoints {
+		if value, ok := dataPoint.Attributes.Value(expected.Key); ok && value == expected.Value && dataPoint.Value > 0 {
+			return
+		}
+	}
+
+	t.Fatalf("expected %s to have a data point for %s, got %+v", metric.Name, expected.Value.Emit(), sum.DataPoints)
+}
+
+func spanNames(spans map[string]tracetest.SpanStub) []string {
+	names := []string{}
+	for name := range spans {
+		names = append(names, name)
+	}
+
+	return names
+}
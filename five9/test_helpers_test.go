@@ -2,6 +2,7 @@ package five9_test
 
 import (
 	"io"
+	"net/http"
 	"os"
 	"testing"
 )
@@ -17,57 +18,66 @@ func createIoReadCloserFromFile(t *testing.T, filePath string) io.ReadCloser {
 	return io.NopCloser(file)
 }
 
-// func createByteSliceFromFile(t *testing.T, filePath string) []byte {
-// 	t.Helper()
+// The below requests run in order when logging in as a supervisor that has no maintenance notices.
+func generateLoginRequestFuncs(t *testing.T) []func(r *http.Request) (*http.Response, error) {
+	t.Helper()
 
-// 	fileBytes, err := os.ReadFile(filePath)
-// 	if err != nil {
-// 		t.Fatalf("File Not Found: %s", filePath)
-// 	}
+	return []func(r *http.Request) (*http.Response, error){
+		func(r *http.Request) (*http.Response, error) { // https://app.five9.com/supsvcs/rs/svc/auth/login
+			return &http.Response{
+				Body:       createIoReadCloserFromFile(t, "test/supervisorLogin_200.json"),
+				StatusCode: http.StatusOK,
+			}, nil
+		},
+		func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/auth/metadata
+			return &http.Response{
+				Body:       createIoReadCloserFromFile(t, "test/auth_metadata_200.json"),
+				StatusCode: http.StatusOK,
+			}, nil
+		},
+		func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/login_state
+			return &http.Response{
+				Body:       createIoReadCloserFromFile(t, "test/loginState_selectStation_200.json"),
+				StatusCode: http.StatusOK,
+			}, nil
+		},
+		func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/session_start?force=true
+			return &http.Response{
+				Body:       http.NoBody,
+				StatusCode: http.StatusNoContent,
+			}, nil
+		},
+		func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/login_state
+			return &http.Response{
+				Body:       createIoReadCloserFromFile(t, "test/loginState_working_200.json"),
+				StatusCode: http.StatusOK,
+			}, nil
+		},
+	}
+}
 
-// 	return fileBytes
-// }
+func createByteSliceFromFile(t *testing.T, filePath string) []byte {
+	t.Helper()
 
-// The below requests run in order when first starting the websocket service.
-// func generateWSLoginRequestFuncs(t *testing.T) []func(r *http.Request) (*http.Response, error) {
-// 	t.Helper()
+	fileBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("File Not Found: %s", filePath)
+	}
 
-// 	return []func(r *http.Request) (*http.Response, error){
-// 		func(r *http.Request) (*http.Response, error) { // https://app.five9.com/supsvcs/rs/svc/auth/login
-// 			return &http.Response{
-// 				Body:       createIoReadCloserFromFile(t, "test/supervisorLogin_200.json"),
-// 				StatusCode: http.StatusOK,
-// 			}, nil
-// 		},
-// 		func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/auth/metadata
-// 			return &http.Response{
-// 				Body:       createIoReadCloserFromFile(t, "test/auth_metadata_200.json"),
-// 				StatusCode: http.StatusOK,
-// 			}, nil
-// 		},
-// 		func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/login_state
-// 			return &http.Response{
-// 				Body:       createIoReadCloserFromFile(t, "test/loginState_selectStation_200.json"),
-// 				StatusCode: http.StatusOK,
-// 			}, nil
-// 		},
-// 		func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/session_start?force=true
-// 			return &http.Response{
-// 				Body:       http.NoBody,
-// 				StatusCode: http.StatusNoContent,
-// 			}, nil
-// 		},
-// 		func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/orgs/:organizationID/users
-// 			return &http.Response{
-// 				Body:       createIoReadCloserFromFile(t, "test/supervisor_getAllUsers_200.json"),
-// 				StatusCode: http.StatusOK,
-// 			}, nil
-// 		},
-// 		func(r *http.Request) (*http.Response, error) { // request_full_statistics
-// 			return &http.Response{
-// 				Body:       http.NoBody,
-// 				StatusCode: http.StatusNoContent,
-// 			}, nil
-// 		},
-// 	}
-// }
+	return fileBytes
+}
+
+// The below requests run in order when starting the websocket service without reading any domain metadata.
+func generateWSStartRequestFuncs(t *testing.T) []func(r *http.Request) (*http.Response, error) {
+	t.Helper()
+
+	return append(
+		generateLoginRequestFuncs(t),
+		func(r *http.Request) (*http.Response, error) { // supsvcs/rs/svc/supervisors/:userID/request_full_statistics
+			return &http.Response{
+				Body:       http.NoBody,
+				StatusCode: http.StatusNoContent,
+			}, nil
+		},
+	)
+}
@@ -3,16 +3,24 @@ package five9
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"net/http"
 
 	"nhooyr.io/websocket"
 )
 
-type webSocketHandler interface {
-	Connect(ctx context.Context, s string, c *http.Client) error
+// WebSocketHandler is the transport used by the supervisor WebSocket. The default implementation dials Five9;
+// see the five9test package for an in-memory implementation that can be used in tests.
+type WebSocketHandler interface {
+	// Connect opens a new connection to connectionURL, closing any previous connection. A rejected handshake
+	// should wrap an *Error with the status of the response, so that an ended session is noticed.
+	Connect(ctx context.Context, connectionURL string, httpClient *http.Client) error
+	// Read blocks until the next text frame arrives, the connection fails or the context is done.
 	Read(ctx context.Context) ([]byte, error)
-	Write(ctx context.Context, b []byte) error
+	// Write sends a text frame to Five9.
+	Write(ctx context.Context, data []byte) error
+	// Close closes the current connection, if any.
 	Close()
 }
 
@@ -31,6 +39,10 @@ func (h *liveWebsocketHandler) Connect(ctx context.Context, connectionURL string
 		HTTPClient: httpClient,
 	})
 	if err != nil {
+		if response != nil && response.Request != nil && response.StatusCode != http.StatusSwitchingProtocols {
+			return fmt.Errorf("%w: %w", err, newResponseError(response.Request, response))
+		}
+
 		return err
 	}
 
@@ -5,7 +5,17 @@ go 1.21.1
 require (
 	github.com/equalsgibson/concur v0.0.1
 	github.com/google/uuid v1.3.1
+	go.opentelemetry.io/otel v1.27.0
+	go.opentelemetry.io/otel/metric v1.27.0
+	go.opentelemetry.io/otel/sdk v1.27.0
+	go.opentelemetry.io/otel/sdk/metric v1.27.0
+	go.opentelemetry.io/otel/trace v1.27.0
 	nhooyr.io/websocket v1.8.7
 )
 
-require github.com/klauspost/compress v1.10.3 // indirect
+require (
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/klauspost/compress v1.10.3 // indirect
+	golang.org/x/sys v0.20.0 // indirect
+)